@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// adminUsers are the usernames allowed to call the admin endpoints.
+var adminUsers []string
+
+// RotateKeyRequest is the optional body of a key rotation.
+type RotateKeyRequest struct {
+	// Algorithm of the new key: HS256, RS256, ES256 or EdDSA. Defaults to JWT_ALGORITHM.
+	Algorithm string `json:"algorithm" example:"EdDSA"`
+}
+
+// AdminMiddleware lets only authenticated users listed in ADMIN_USERS through.
+func AdminMiddleware(next http.Handler) http.Handler {
+	return AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := userFromContext(r.Context())
+		if !ok || !containsString(adminUsers, user.Username) {
+			writeError(w, r, http.StatusForbidden, "Admin access required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// serverKeyring returns the server's keyring, writing an error if it signs with a fixed secret.
+func serverKeyring(w http.ResponseWriter, r *http.Request) (*Keyring, bool) {
+	keyring, ok := tokenKeys.(*Keyring)
+	if !ok {
+		writeError(w, r, http.StatusConflict, "Key management requires KEYRING_FILE, the server signs with a fixed JWT secret")
+		return nil, false
+	}
+	return keyring, true
+}
+
+// ListKeysHandler godoc
+// @Summary List signing keys
+// @Description List the keys in the keyring: the active key that signs new tokens, superseded keys still verifying older tokens, and expired or retired keys. Requires an admin user and KEYRING_FILE.
+// @Tags admin
+// @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {array} KeyInfo "Keys, oldest first"
+// @Failure 401 {object} ErrorResponse "Missing or invalid auth token"
+// @Failure 403 {object} ErrorResponse "Admin access required"
+// @Failure 409 {object} ErrorResponse "The server is not using a keyring"
+// @Failure 500 {object} ErrorResponse "Error reading the keyring"
+// @Router /admin/keys [get]
+func ListKeysHandler(w http.ResponseWriter, r *http.Request) {
+	keyring, ok := serverKeyring(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := keyring.List()
+	if err != nil {
+		log.Printf("Listing keys failed: %s", err)
+		writeError(w, r, http.StatusInternalServerError, "Error reading the keyring")
+		return
+	}
+	writeResponse(w, r, http.StatusOK, keys)
+}
+
+// RotateKeyHandler godoc
+// @Summary Rotate the signing key
+// @Description Generate a new active signing key. The previous key keeps verifying tokens until they have all expired (24 hours), unless it is retired earlier. Requires an admin user and KEYRING_FILE.
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Param request body RotateKeyRequest false "Algorithm of the new key"
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {object} KeyInfo "The new active key"
+// @Failure 400 {object} ErrorResponse "Invalid request payload or algorithm"
+// @Failure 401 {object} ErrorResponse "Missing or invalid auth token"
+// @Failure 403 {object} ErrorResponse "Admin access required"
+// @Failure 409 {object} ErrorResponse "The server is not using a keyring"
+// @Failure 500 {object} ErrorResponse "Error rotating the key"
+// @Router /admin/keys/rotate [post]
+func RotateKeyHandler(w http.ResponseWriter, r *http.Request) {
+	keyring, ok := serverKeyring(w, r)
+	if !ok {
+		return
+	}
+
+	var req RotateKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if req.Algorithm != "" && !containsString(signingAlgorithms, req.Algorithm) {
+		writeError(w, r, http.StatusBadRequest, "Invalid algorithm: must be one of HS256, RS256, ES256 or EdDSA")
+		return
+	}
+
+	key, err := keyring.Rotate(req.Algorithm)
+	if err != nil {
+		log.Printf("Rotating the signing key failed: %s", err)
+		writeError(w, r, http.StatusInternalServerError, "Error rotating the key")
+		return
+	}
+
+	user, _ := userFromContext(r.Context())
+	log.Printf("User %s rotated the signing key, %s is now active", user.Username, key.ID)
+	writeResponse(w, r, http.StatusOK, key)
+}
+
+// RetireKeyHandler godoc
+// @Summary Retire a signing key
+// @Description Stop accepting tokens signed with a superseded key before its verification window ends. The active key cannot be retired. Requires an admin user and KEYRING_FILE.
+// @Tags admin
+// @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Param kid path string true "Key ID"
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {object} KeyInfo "The retired key"
+// @Failure 401 {object} ErrorResponse "Missing or invalid auth token"
+// @Failure 403 {object} ErrorResponse "Admin access required"
+// @Failure 404 {object} ErrorResponse "Unknown key"
+// @Failure 409 {object} ErrorResponse "The key is active, or the server is not using a keyring"
+// @Failure 500 {object} ErrorResponse "Error retiring the key"
+// @Router /admin/keys/{kid}/retire [post]
+func RetireKeyHandler(w http.ResponseWriter, r *http.Request) {
+	keyring, ok := serverKeyring(w, r)
+	if !ok {
+		return
+	}
+
+	kid := mux.Vars(r)["kid"]
+	key, err := keyring.Retire(kid)
+	switch {
+	case errors.Is(err, errKeyNotFound):
+		writeError(w, r, http.StatusNotFound, "Unknown key "+kid)
+		return
+	case errors.Is(err, errRetireActiveKey):
+		writeError(w, r, http.StatusConflict, "Cannot retire the active key, rotate first")
+		return
+	case err != nil:
+		log.Printf("Retiring key %s failed: %s", kid, err)
+		writeError(w, r, http.StatusInternalServerError, "Error retiring the key")
+		return
+	}
+
+	user, _ := userFromContext(r.Context())
+	log.Printf("User %s retired signing key %s", user.Username, kid)
+	writeResponse(w, r, http.StatusOK, key)
+}
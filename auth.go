@@ -2,86 +2,155 @@ package main
 
 // swagger:meta
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
 )
 
-var secretKey = []byte("myakhere_xyzabc1234")
-
 // AuthHandler godoc
 // @Summary Authenticate user and generate access token
-// @Description Authenticate user with credentials and generate access token
+// @Description Authenticate user with credentials and generate access token. The token's sub claim holds the username.
+// @Description After 5 consecutive failed logins an account is locked for 1 minute, doubling with every further failure up to 1 hour; a successful login resets the count. An admin can also lock an account until it is unlocked.
 // @Tags authentication
 // @Accept  json
 // @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
 // @Param credentials body Credentials true "User credentials"
 // @Success 200 {object} map[string]string "JWT token"
 // @Failure 400 {object} ErrorResponse "Invalid request payload"
 // @Failure 401 {object} ErrorResponse "Invalid credentials"
+// @Failure 403 {object} ErrorResponse "Account is disabled or locked"
 // @Failure 500 {object} ErrorResponse "Error generating token"
+// @Failure 503 {object} ErrorResponse "No signing key is loaded yet"
 // @Router /auth [post]
 func AuthHandler(w http.ResponseWriter, r *http.Request) {
 	var creds Credentials
 	err := json.NewDecoder(r.Body).Decode(&creds)
 	if err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 
-	// Replace with your authentication logic
-	if creds.Username == "snifyak" && creds.Password == "123@snifyak@123" {
-		tokenString, err := generateToken()
-		if err != nil {
-			writeJSONError(w, http.StatusInternalServerError, "Error generating token")
-			return
-		}
+	user, err := authenticate(r.Context(), users, creds.Username, creds.Password)
+	switch {
+	case errors.Is(err, errInvalidCredentials):
+		writeError(w, r, http.StatusUnauthorized, "Invalid credentials")
+		return
+	case errors.Is(err, errUserDisabled):
+		writeError(w, r, http.StatusForbidden, "Account is disabled")
+		return
+	case errors.Is(err, errUserLocked):
+		writeError(w, r, http.StatusForbidden, "Account is locked")
+		return
+	case err != nil:
+		log.Printf("Authenticating %s failed: %s", creds.Username, err)
+		writeError(w, r, http.StatusInternalServerError, "Error checking credentials")
+		return
+	}
 
-		response := map[string]string{"token": tokenString}
-		writeJSONResponse(w, http.StatusOK, response)
-	} else {
-		writeJSONError(w, http.StatusUnauthorized, "Invalid credentials")
+	tokenString, err := generateToken(user.Username)
+	if errors.Is(err, errNoSigningKey) {
+		writeError(w, r, http.StatusServiceUnavailable, "Error: No signing key is loaded, please retry shortly")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "Error generating token")
+		return
 	}
-}
 
-// writeJSONResponse writes a JSON response with the specified status code and data.
-func writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	response := map[string]string{"token": tokenString}
+	writeResponse(w, r, http.StatusOK, response)
 }
 
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			writeJSONError(w, http.StatusUnauthorized, "Missing auth token")
+			writeError(w, r, http.StatusUnauthorized, "Missing auth token")
 			return
 		}
 
 		authToken := strings.TrimPrefix(authHeader, "Bearer ")
 
-		_, err := validateToken(authToken)
+		user, err := authorizeToken(r.Context(), authToken)
+		if errors.Is(err, errNoSigningKey) {
+			writeError(w, r, http.StatusServiceUnavailable, "Error: No signing key is loaded, please retry shortly")
+			return
+		}
 		if err != nil {
-			writeJSONError(w, http.StatusUnauthorized, "Invalid auth token")
+			writeError(w, r, http.StatusUnauthorized, "Invalid auth token")
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(contextWithUser(r.Context(), user)))
 	})
 }
 
-func generateToken() (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["exp"] = time.Now().Add(time.Hour * 24).Unix() // Token expires in 24 hours
+// userContextKey is the context key of the authenticated user.
+type userContextKey struct{}
+
+// contextWithUser returns a copy of ctx carrying the authenticated user.
+func contextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// userFromContext returns the user authenticated by AuthMiddleware, if any.
+func userFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
 
-	tokenString, err := token.SignedString(secretKey)
+// authorizeToken validates a token and returns the user named by its sub claim. Tokens of users
+// that have since been deleted or disabled are rejected; locked users keep their tokens, so that
+// failed logins by someone else cannot cut them off.
+func authorizeToken(ctx context.Context, tokenString string) (User, error) {
+	token, err := validateToken(tokenString)
+	if err != nil {
+		return User{}, err
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	username, _ := claims["sub"].(string)
+	if username == "" {
+		return User{}, errors.New("token has no subject")
+	}
+
+	user, err := users.Get(ctx, username)
+	if err != nil {
+		return User{}, err
+	}
+	if user.Disabled {
+		return User{}, errUserDisabled
+	}
+	return user, nil
+}
+
+// tokenLifetime is how long a token is valid after it is issued.
+const tokenLifetime = 24 * time.Hour
+
+// generateToken issues a token for username, carried in the sub claim. It is signed with the
+// current key, whose id is set as the kid header.
+func generateToken(username string) (string, error) {
+	key := tokenKeys.Key()
+	if key == nil {
+		return "", errNoSigningKey
+	}
+	token := jwt.NewWithClaims(key.method, jwt.MapClaims{
+		"sub": username,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(tokenLifetime).Unix(),
+	})
+	token.Header["kid"] = key.id
+
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		// Return a JSON response for the error
 		return "", errors.New("error generating token")
@@ -90,22 +159,32 @@ func generateToken() (string, error) {
 	return tokenString, nil
 }
 
+// validateToken checks a token's signature and expiry. The key is picked by the token's kid
+// header, and the token must use that key's algorithm.
 func validateToken(tokenString string) (*jwt.Token, error) {
+	if len(tokenKeys.VerificationKeys()) == 0 {
+		return nil, errNoSigningKey
+	}
+
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := tokenKeys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+
 		// Check the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
-		return secretKey, nil
-	})
+		return key.public, nil
+	}, jwt.WithValidMethods(signingAlgorithms), jwt.WithExpirationRequired())
 
 	if err != nil {
 		// If the token is expired, return a specific JSON response
-		if ve, ok := err.(*jwt.ValidationError); ok {
-			if ve.Errors&jwt.ValidationErrorExpired != 0 {
-				return nil, errors.New("token has expired")
-			}
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("token has expired")
 		}
 
 		// For other errors, return a general JSON response
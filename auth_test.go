@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAuthHandler checks the status of logins by regular, unknown, disabled and locked users.
+func TestAuthHandler(t *testing.T) {
+	store := useUserStore(t)
+	newTestUser(t, store, "bob")
+	carol := newTestUser(t, store, "carol")
+	carol.Disabled = true
+	dave := newTestUser(t, store, "dave")
+	dave.Locked = true
+	for _, user := range []User{carol, dave} {
+		if err := store.Update(context.Background(), user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"username":"bob","password":"` + testPassword + `"}`, http.StatusOK},
+		{`{"username":"bob","password":"wrong"}`, http.StatusUnauthorized},
+		{`{"username":"nobody","password":"` + testPassword + `"}`, http.StatusUnauthorized},
+		{`{"username":"carol","password":"` + testPassword + `"}`, http.StatusForbidden},
+		{`{"username":"dave","password":"` + testPassword + `"}`, http.StatusForbidden},
+		{`{"username":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		AuthHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth", strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.body, rec.Code, tt.status, rec.Body)
+		}
+	}
+}
+
+// TestAuthMiddleware checks that tokens are only accepted while their user exists and is enabled.
+func TestAuthMiddleware(t *testing.T) {
+	ctx := context.Background()
+	store := useUserStore(t)
+	bob := newTestUser(t, store, "bob")
+
+	var seen string
+	handler := AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _ := userFromContext(r.Context())
+		seen = user.Username
+	}))
+	status := func(authorization string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/countries", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	token, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := status("Bearer " + token); got != http.StatusOK || seen != "bob" {
+		t.Errorf("valid token: status %d as %q", got, seen)
+	}
+	if got := status(""); got != http.StatusUnauthorized {
+		t.Errorf("no token: status %d", got)
+	}
+	if got := status("Bearer not-a-jwt"); got != http.StatusUnauthorized {
+		t.Errorf("malformed token: status %d", got)
+	}
+
+	bob.Locked = true
+	if err := store.Update(ctx, bob); err != nil {
+		t.Fatal(err)
+	}
+	if got := status("Bearer " + token); got != http.StatusOK {
+		t.Errorf("token of a locked user: status %d, want 200", got)
+	}
+
+	bob.Disabled = true
+	if err := store.Update(ctx, bob); err != nil {
+		t.Fatal(err)
+	}
+	if got := status("Bearer " + token); got != http.StatusUnauthorized {
+		t.Errorf("token of a disabled user: status %d", got)
+	}
+
+	if err := store.Delete(ctx, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if got := status("Bearer " + token); got != http.StatusUnauthorized {
+		t.Errorf("token of a deleted user: status %d", got)
+	}
+}
+
+// TestWithoutSigningKey checks that signing and checking tokens and cursors fail with
+// errNoSigningKey, which the handlers report as 503, while no key is loaded.
+func TestWithoutSigningKey(t *testing.T) {
+	useCatalog(t, testCountries(t))
+	newTestUser(t, useUserStore(t), "bob")
+	token, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useSigningKey(t, nil)
+
+	if _, err := generateToken("bob"); !errors.Is(err, errNoSigningKey) {
+		t.Errorf("generateToken: got %v, want errNoSigningKey", err)
+	}
+	if _, err := validateToken(token); !errors.Is(err, errNoSigningKey) {
+		t.Errorf("validateToken: got %v, want errNoSigningKey", err)
+	}
+	if _, err := (pageCursor{}).encode(); !errors.Is(err, errNoSigningKey) {
+		t.Errorf("encoding a cursor: got %v, want errNoSigningKey", err)
+	}
+
+	rec := httptest.NewRecorder()
+	AuthHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth", strings.NewReader(`{"username":"bob","password":"`+testPassword+`"}`)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("login: status %d, want 503", rec.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/countries", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	AuthMiddleware(http.HandlerFunc(CountriesListHandler)).ServeHTTP(rec, r)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("authenticated request: status %d, want 503", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?page_size=1", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("filter page with a next cursor: status %d, want 503", rec.Code)
+	}
+}
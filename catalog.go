@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// catalogRetryInterval is how often a catalog that has never loaded retries its first refresh.
+const catalogRetryInterval = time.Minute
+
+// errCatalogNotLoaded is returned when the catalog has no data to serve yet.
+var errCatalogNotLoaded = errors.New("country catalog is not loaded yet")
+
+// catalog is the country catalog shared by all HTTP handlers.
+var catalog *Catalog
+
+// Catalog keeps the full country dataset in memory and refreshes it in the background.
+// A failed refresh keeps the last good copy in place.
+type Catalog struct {
+	provider CountryProvider
+	interval time.Duration
+
+	mu        sync.RWMutex
+	countries []Country
+	byCode    map[string]int
+	status    CatalogStatus
+}
+
+// CatalogStatus describes the state of the country catalog and its last refresh.
+type CatalogStatus struct {
+	Loaded          bool      `json:"loaded"`
+	Countries       int       `json:"countries"`
+	RefreshInterval string    `json:"refresh_interval"`
+	LastAttempt     time.Time `json:"last_attempt"`
+	LastSuccess     time.Time `json:"last_success"`
+	NextRefresh     time.Time `json:"next_refresh"`
+	LastError       string    `json:"last_error,omitempty"`
+}
+
+// NewCatalog creates a catalog that reloads all countries from provider every interval.
+// An interval of zero disables background refreshes once the catalog has loaded.
+func NewCatalog(provider CountryProvider, interval time.Duration) *Catalog {
+	return &Catalog{
+		provider: provider,
+		interval: interval,
+		status:   CatalogStatus{RefreshInterval: interval.String()},
+	}
+}
+
+// Refresh reloads the dataset. On failure the previously loaded countries are kept.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	started := time.Now()
+	countries, err := c.provider.All(ctx)
+	if err == nil && len(countries) == 0 {
+		err = errors.New("upstream returned no countries")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status.LastAttempt = started
+	if c.interval > 0 {
+		c.status.NextRefresh = started.Add(c.interval)
+	}
+	if err != nil {
+		if !c.status.Loaded && (c.interval <= 0 || c.interval > catalogRetryInterval) {
+			c.status.NextRefresh = started.Add(catalogRetryInterval)
+		}
+		c.status.LastError = err.Error()
+		return err
+	}
+	if c.interval <= 0 {
+		c.status.NextRefresh = time.Time{}
+	}
+
+	c.countries = countries
+	c.byCode = indexCountryCodes(countries)
+	c.status.Loaded = true
+	c.status.Countries = len(countries)
+	c.status.LastSuccess = started
+	c.status.LastError = ""
+	return nil
+}
+
+// Run refreshes the catalog every interval until ctx is cancelled. While no
+// data has been loaded yet, refreshes are retried every catalogRetryInterval,
+// even when background refreshes are disabled.
+func (c *Catalog) Run(ctx context.Context) {
+	for {
+		wait := c.interval
+		if loaded := c.Status().Loaded; !loaded && (wait <= 0 || wait > catalogRetryInterval) {
+			wait = catalogRetryInterval
+		} else if loaded && wait <= 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+			if err := c.Refresh(ctx); err != nil {
+				log.Printf("Country catalog refresh failed, serving last good copy: %s", err)
+			} else {
+				log.Printf("Country catalog refreshed: %d countries", c.Status().Countries)
+			}
+		}
+	}
+}
+
+// Countries returns a copy of the loaded country list that callers may reorder freely.
+func (c *Catalog) Countries() ([]Country, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.status.Loaded {
+		return nil, errCatalogNotLoaded
+	}
+
+	countries := make([]Country, len(c.countries))
+	copy(countries, c.countries)
+	return countries, nil
+}
+
+// ByCode returns the country with the given cca2, cca3, ccn3 or cioc code, case-insensitively.
+func (c *Catalog) ByCode(code string) (Country, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.status.Loaded {
+		return Country{}, errCatalogNotLoaded
+	}
+
+	i, ok := c.byCode[strings.ToUpper(code)]
+	if !ok {
+		return Country{}, errCountryNotFound
+	}
+
+	return c.countries[i], nil
+}
+
+// Status returns a snapshot of the catalog refresh status.
+func (c *Catalog) Status() CatalogStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.status
+}
+
+// indexCountryCodes maps every upper-cased country code to the country's position in countries.
+// IOC and numeric codes are indexed first so that ISO alpha codes win when codes collide.
+func indexCountryCodes(countries []Country) map[string]int {
+	index := make(map[string]int, len(countries)*4)
+
+	for i, country := range countries {
+		for _, code := range []string{country.CIOC, country.CCN3} {
+			if code != "" {
+				index[strings.ToUpper(code)] = i
+			}
+		}
+	}
+	for i, country := range countries {
+		for _, code := range []string{country.CCA2, country.CCA3} {
+			if code != "" {
+				index[strings.ToUpper(code)] = i
+			}
+		}
+	}
+
+	return index
+}
+
+// CatalogStatusHandler godoc
+// @Summary Get the country catalog refresh status
+// @Description Get whether the in-memory country catalog is loaded, how many countries it holds and the outcome of the last refresh
+// @Tags catalog
+// @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Success 200 {object} CatalogStatus "catalog status"
+// @Failure 503 {object} CatalogStatus "catalog has not been loaded yet"
+// @Router /catalog/status [get]
+func CatalogStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status := catalog.Status()
+	if !status.Loaded {
+		writeResponse(w, r, http.StatusServiceUnavailable, status)
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, status)
+}
+
+// catalogCountries returns the catalog's countries, writing a 503 error response if they are unavailable.
+func catalogCountries(w http.ResponseWriter, r *http.Request) ([]Country, bool) {
+	countries, err := catalog.Countries()
+	if err != nil {
+		writeError(w, r, http.StatusServiceUnavailable, "Error: Country data is not available yet, please retry shortly")
+		return nil, false
+	}
+
+	return countries, true
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCatalogRefreshKeepsLastGoodCopy checks that a failing or empty upstream does not replace
+// countries that were already loaded.
+func TestCatalogRefreshKeepsLastGoodCopy(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider(nil)
+	c := NewCatalog(provider, 0)
+
+	if err := c.Refresh(ctx); err == nil {
+		t.Fatal("refresh from an empty provider succeeded")
+	}
+	if _, err := c.Countries(); !errors.Is(err, errCatalogNotLoaded) {
+		t.Fatalf("Countries before the first load: got %v, want errCatalogNotLoaded", err)
+	}
+
+	provider.SetCountries([]Country{
+		{Name: CountryName{Common: "Norway"}, CCA2: "NO", CCA3: "NOR", CCN3: "578", CIOC: "NOR"},
+		{Name: CountryName{Common: "Sweden"}, CCA2: "SE", CCA3: "SWE", CCN3: "752", CIOC: "SWE"},
+	})
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+
+	provider.SetErr(errors.New("upstream down"))
+	if err := c.Refresh(ctx); err == nil {
+		t.Fatal("refresh from a failing provider succeeded")
+	}
+	status := c.Status()
+	if !status.Loaded || status.Countries != 2 || status.LastError != "upstream down" {
+		t.Errorf("status after failed refresh: %+v", status)
+	}
+	if countries, err := c.Countries(); err != nil || len(countries) != 2 {
+		t.Errorf("Countries after failed refresh: got %d countries, %v", len(countries), err)
+	}
+
+	provider.SetErr(nil)
+	provider.SetCountries([]Country{{Name: CountryName{Common: "Norway"}, CCA2: "NO", CCA3: "NOR"}})
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("refresh after recovery: %v", err)
+	}
+	if countries, err := c.Countries(); err != nil || len(countries) != 1 {
+		t.Errorf("Countries after recovery: got %d countries, %v", len(countries), err)
+	}
+	if c.Status().LastError != "" {
+		t.Errorf("LastError after recovery: %q", c.Status().LastError)
+	}
+}
+
+// TestCatalogCountriesReturnsCopy checks that callers reordering the returned slice do not
+// change what the catalog serves next.
+func TestCatalogCountriesReturnsCopy(t *testing.T) {
+	c := NewCatalog(NewMemoryProvider([]Country{{CCA3: "NOR"}, {CCA3: "SWE"}}), 0)
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := c.Countries()
+	first[0], first[1] = first[1], first[0]
+
+	second, _ := c.Countries()
+	if second[0].CCA3 != "NOR" {
+		t.Errorf("catalog order changed through a returned slice: %+v", second)
+	}
+}
+
+// TestCatalogByCode checks code lookups across cca2, cca3, ccn3 and cioc, and that an ISO alpha
+// code wins over an IOC code that collides with it.
+func TestCatalogByCode(t *testing.T) {
+	c := NewCatalog(NewMemoryProvider([]Country{
+		{CCA2: "DE", CCA3: "DEU", CCN3: "276", CIOC: "GER"},
+		{CCA2: "GE", CCA3: "GEO", CCN3: "268", CIOC: "GEO"},
+		{CCA2: "NL", CCA3: "NLD", CCN3: "528", CIOC: "NED"},
+		{CCA2: "XG", CCA3: "GER", CCN3: "999"},
+	}), 0)
+	if _, err := c.ByCode("DE"); !errors.Is(err, errCatalogNotLoaded) {
+		t.Fatalf("ByCode before the first load: got %v, want errCatalogNotLoaded", err)
+	}
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"de", "DEU"},
+		{"DEU", "DEU"},
+		{"276", "DEU"},
+		{"ned", "NLD"},
+		{"GEO", "GEO"},
+		{"GER", "GER"},
+		{"999", "GER"},
+	}
+	for _, tt := range tests {
+		country, err := c.ByCode(tt.code)
+		if err != nil || country.CCA3 != tt.want {
+			t.Errorf("ByCode(%q): got %q, %v, want %q", tt.code, country.CCA3, err, tt.want)
+		}
+	}
+	for _, code := range []string{"", "XX", "000"} {
+		if _, err := c.ByCode(code); !errors.Is(err, errCountryNotFound) {
+			t.Errorf("ByCode(%q): got %v, want errCountryNotFound", code, err)
+		}
+	}
+}
+
+// TestCatalogRetriesFirstLoad checks that a catalog without background refreshes still retries
+// a failed first load, and stops scheduling refreshes once it has loaded.
+func TestCatalogRetriesFirstLoad(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider(nil)
+	provider.SetErr(errors.New("upstream down"))
+	c := NewCatalog(provider, 0)
+
+	started := time.Now()
+	if err := c.Refresh(ctx); err == nil {
+		t.Fatal("refresh from a failing provider succeeded")
+	}
+	if next := c.Status().NextRefresh; next.Before(started.Add(catalogRetryInterval)) || next.After(time.Now().Add(catalogRetryInterval)) {
+		t.Errorf("next refresh at %v, want %s after the failed load", next, catalogRetryInterval)
+	}
+
+	// Run keeps waiting to retry until it is cancelled
+	runCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	c.Run(runCtx)
+	if runCtx.Err() == nil {
+		t.Error("Run returned before the catalog loaded")
+	}
+
+	provider.SetErr(nil)
+	provider.SetCountries([]Country{{CCA3: "NOR"}})
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if next := c.Status().NextRefresh; !next.IsZero() {
+		t.Errorf("next refresh at %v after loading with refreshes disabled", next)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Run kept going after the catalog loaded with refreshes disabled")
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Country sources supported by COUNTRY_SOURCE.
+const (
+	sourceRESTCountries = "restcountries"
+	sourceFile          = "file"
+	sourceEmbedded      = "embedded"
+)
+
+// Deployment environments supported by APP_ENV.
+const (
+	envDevelopment = "development"
+	envProduction  = "production"
+)
+
+// defaultRESTCountriesURL is the base URL of the public restcountries API.
+const defaultRESTCountriesURL = "https://restcountries.com/v3.1"
+
+// Config holds the runtime configuration of the server, read from environment variables.
+type Config struct {
+	// Port is the HTTP listen port (PORT).
+	Port string
+	// GRPCPort is the gRPC listen port (GRPC_PORT).
+	GRPCPort string
+	// CountrySource selects where the catalog loads countries from (COUNTRY_SOURCE):
+	// "restcountries", "file" or "embedded".
+	CountrySource string
+	// RESTCountriesURL is the base URL of the restcountries v3.1 compatible API (RESTCOUNTRIES_URL).
+	RESTCountriesURL string
+	// SnapshotFile is the restcountries v3.1 JSON file used by the file source (SNAPSHOT_FILE).
+	SnapshotFile string
+	// RefreshInterval is how often the country catalog is reloaded (CATALOG_REFRESH_INTERVAL).
+	RefreshInterval time.Duration
+	// UserStore selects where user accounts are kept (USER_STORE): "file" or "sqlite".
+	UserStore string
+	// UserStorePath is the JSON file or SQLite database holding the users (USER_STORE_PATH).
+	UserStorePath string
+	// PasswordHash is the algorithm for new password hashes (PASSWORD_HASH): "argon2id" or "bcrypt".
+	PasswordHash string
+	// BootstrapUser and BootstrapPassword create a first user when the store is empty
+	// (BOOTSTRAP_USER, BOOTSTRAP_PASSWORD).
+	BootstrapUser     string
+	BootstrapPassword string
+	// Environment is the deployment environment (APP_ENV): "development" or "production".
+	// Production refuses to start without a strong JWT secret.
+	Environment string
+	// JWTAlgorithm is the token signing algorithm (JWT_ALGORITHM): HS256, RS256, ES256 or EdDSA.
+	// The asymmetric algorithms take a PEM encoded private key as their secret.
+	JWTAlgorithm string
+	// JWTSecretEnv reports whether the JWT secret is set directly in JWT_SECRET.
+	JWTSecretEnv bool
+	// JWTSecretFile is a file holding the JWT secret, e.g. a mounted secret (JWT_SECRET_FILE).
+	JWTSecretFile string
+	// JWTSecretCommand is a shell command printing the JWT secret (JWT_SECRET_COMMAND).
+	JWTSecretCommand string
+	// KeyringFile is a keyring of rotating signing keys (KEYRING_FILE), used instead of a JWT secret.
+	KeyringFile string
+	// AdminUsers are the usernames allowed to call the admin endpoints (ADMIN_USERS, comma-separated).
+	AdminUsers []string
+}
+
+// Production reports whether the server runs in production.
+func (c Config) Production() bool {
+	return c.Environment == envProduction
+}
+
+// loadConfig reads the configuration from the environment, applying defaults for unset values.
+func loadConfig() (Config, error) {
+	cfg := Config{
+		Port:              getEnv("PORT", "8080"),
+		GRPCPort:          getEnv("GRPC_PORT", "9090"),
+		RESTCountriesURL:  getEnv("RESTCOUNTRIES_URL", defaultRESTCountriesURL),
+		SnapshotFile:      os.Getenv("SNAPSHOT_FILE"),
+		RefreshInterval:   6 * time.Hour,
+		BootstrapUser:     os.Getenv("BOOTSTRAP_USER"),
+		BootstrapPassword: os.Getenv("BOOTSTRAP_PASSWORD"),
+		Environment:       getEnv("APP_ENV", envDevelopment),
+		JWTSecretEnv:      os.Getenv("JWT_SECRET") != "",
+		JWTSecretFile:     os.Getenv("JWT_SECRET_FILE"),
+		JWTSecretCommand:  os.Getenv("JWT_SECRET_COMMAND"),
+	}
+
+	for _, username := range strings.Split(os.Getenv("ADMIN_USERS"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			cfg.AdminUsers = append(cfg.AdminUsers, username)
+		}
+	}
+
+	// A snapshot file on its own implies the file source.
+	defaultSource := sourceRESTCountries
+	if cfg.SnapshotFile != "" {
+		defaultSource = sourceFile
+	}
+	cfg.CountrySource = getEnv("COUNTRY_SOURCE", defaultSource)
+
+	switch cfg.CountrySource {
+	case sourceRESTCountries, sourceEmbedded:
+	case sourceFile:
+		if cfg.SnapshotFile == "" {
+			return Config{}, fmt.Errorf("COUNTRY_SOURCE=file requires SNAPSHOT_FILE to be set")
+		}
+	default:
+		return Config{}, fmt.Errorf("invalid COUNTRY_SOURCE %q: must be one of %s, %s or %s", cfg.CountrySource, sourceRESTCountries, sourceFile, sourceEmbedded)
+	}
+
+	if v := os.Getenv("CATALOG_REFRESH_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval < 0 {
+			return Config{}, fmt.Errorf("invalid CATALOG_REFRESH_INTERVAL %q: must be a duration such as 30m or 6h", v)
+		}
+		cfg.RefreshInterval = interval
+	}
+
+	if err := cfg.readUserStoreSettings(); err != nil {
+		return Config{}, err
+	}
+
+	if (cfg.BootstrapUser == "") != (cfg.BootstrapPassword == "") {
+		return Config{}, fmt.Errorf("BOOTSTRAP_USER and BOOTSTRAP_PASSWORD must be set together")
+	}
+
+	if cfg.Environment != envDevelopment && cfg.Environment != envProduction {
+		return Config{}, fmt.Errorf("invalid APP_ENV %q: must be %s or %s", cfg.Environment, envDevelopment, envProduction)
+	}
+
+	// The embedded dataset is a sample, not the full catalog.
+	if cfg.Production() && cfg.CountrySource == sourceEmbedded {
+		return Config{}, fmt.Errorf("COUNTRY_SOURCE=embedded serves a sample dataset and is not allowed in production, use %s or a SNAPSHOT_FILE", sourceRESTCountries)
+	}
+
+	if err := cfg.readKeyringSettings(); err != nil {
+		return Config{}, err
+	}
+
+	secretSources := 0
+	for _, set := range []bool{cfg.JWTSecretEnv, cfg.JWTSecretFile != "", cfg.JWTSecretCommand != ""} {
+		if set {
+			secretSources++
+		}
+	}
+	if secretSources > 1 {
+		return Config{}, fmt.Errorf("set only one of JWT_SECRET, JWT_SECRET_FILE and JWT_SECRET_COMMAND")
+	}
+	if secretSources > 0 && cfg.KeyringFile != "" {
+		return Config{}, fmt.Errorf("KEYRING_FILE cannot be combined with JWT_SECRET, JWT_SECRET_FILE or JWT_SECRET_COMMAND")
+	}
+
+	if cfg.GRPCPort == cfg.Port {
+		return Config{}, fmt.Errorf("GRPC_PORT and PORT must differ, both are %s", cfg.Port)
+	}
+
+	// The embedded dataset never changes, so there is nothing to refresh.
+	if cfg.CountrySource == sourceEmbedded {
+		cfg.RefreshInterval = 0
+	}
+
+	return cfg, nil
+}
+
+// loadUserStoreConfig reads only the user store settings, which is all the user commands need,
+// so they keep working while unrelated server settings are invalid.
+func loadUserStoreConfig() (Config, error) {
+	var cfg Config
+	if err := cfg.readUserStoreSettings(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// loadKeyringConfig reads only the keyring settings, which is all the key commands need.
+func loadKeyringConfig() (Config, error) {
+	var cfg Config
+	if err := cfg.readKeyringSettings(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// readUserStoreSettings reads and checks USER_STORE, USER_STORE_PATH and PASSWORD_HASH.
+func (c *Config) readUserStoreSettings() error {
+	c.UserStore = getEnv("USER_STORE", userStoreFile)
+	switch c.UserStore {
+	case userStoreFile:
+		c.UserStorePath = getEnv("USER_STORE_PATH", "users.json")
+	case userStoreSQLite:
+		c.UserStorePath = getEnv("USER_STORE_PATH", "users.db")
+	default:
+		return fmt.Errorf("invalid USER_STORE %q: must be %s or %s", c.UserStore, userStoreFile, userStoreSQLite)
+	}
+
+	c.PasswordHash = getEnv("PASSWORD_HASH", hashArgon2id)
+	if c.PasswordHash != hashArgon2id && c.PasswordHash != hashBcrypt {
+		return fmt.Errorf("invalid PASSWORD_HASH %q: must be %s or %s", c.PasswordHash, hashArgon2id, hashBcrypt)
+	}
+	return nil
+}
+
+// readKeyringSettings reads and checks KEYRING_FILE and JWT_ALGORITHM.
+func (c *Config) readKeyringSettings() error {
+	c.KeyringFile = os.Getenv("KEYRING_FILE")
+	c.JWTAlgorithm = getEnv("JWT_ALGORITHM", algHS256)
+	if !containsString(signingAlgorithms, c.JWTAlgorithm) {
+		return fmt.Errorf("invalid JWT_ALGORITHM %q: must be one of %s", c.JWTAlgorithm, strings.Join(signingAlgorithms, ", "))
+	}
+	return nil
+}
+
+// getEnv returns the value of the environment variable key, or fallback if it is unset or empty.
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLoadConfigCountrySource checks the country sources accepted in development and production.
+func TestLoadConfigCountrySource(t *testing.T) {
+	tests := []struct {
+		env     map[string]string
+		wantErr string
+	}{
+		{map[string]string{"COUNTRY_SOURCE": sourceEmbedded}, ""},
+		{map[string]string{"COUNTRY_SOURCE": sourceEmbedded, "APP_ENV": envProduction, "JWT_SECRET": strings.Repeat("s", minSecretLength)}, "sample dataset"},
+		{map[string]string{"APP_ENV": envProduction, "JWT_SECRET": strings.Repeat("s", minSecretLength)}, ""},
+		{map[string]string{"COUNTRY_SOURCE": sourceFile}, "SNAPSHOT_FILE"},
+		{map[string]string{"COUNTRY_SOURCE": "elsewhere"}, "invalid COUNTRY_SOURCE"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.env["COUNTRY_SOURCE"]+" "+tt.env["APP_ENV"]), func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := loadConfig()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("got %v, want an error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
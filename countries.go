@@ -2,114 +2,354 @@ package main
 
 // swagger:meta
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"sort"
+	"net/url"
 	"strconv"
 	"strings"
+
+	"country_assignment_api/countrypb"
+
+	"github.com/gorilla/mux"
 )
 
+// maxBatchCodes is the maximum number of country codes accepted by a single batch lookup.
+const maxBatchCodes = 100
+
 // CountriesListHandler retrieves a list of all countries details - For testing purpose only.
 // CountriesListHandler godoc
 // @Summary Retrieve a list of all countries details
-// @Description Retrieve a list of all countries - For testing purpose only. Because it retrieves all the details it will take some time to load (8-12 seconds)
+// @Description Retrieve a list of all countries - For testing purpose only. Served from the in-memory country catalog.
 // @Tags countries
 // @Accept  json
 // @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Produce  text/csv
+// @Produce  application/x-protobuf
+// @Produce  application/geo+json
+// @Param fields query string false "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element."
+// @Param format query string false "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv, application/geo+json or application/x-protobuf)." Enums(json, xml, yaml, csv, geojson, protobuf)
+// @Param point query string false "With GeoJSON output, place each feature at the country centroid (latlng) or its capital (capitalInfo.latlng)." Enums(centroid, capital) default(centroid)
+// @Param bom query boolean false "With CSV output, prefix the body with a UTF-8 byte order mark for Excel."
 // @Security ApiKeyAuth
 // @Param Authorization header string true "JWT token"
-// @Success 200 {object} map[string]interface{} "country data"
+// @Success 200 {object} CountryListResponse "country data"
+// @Failure 400 {object} ErrorResponse "Invalid fields parameter"
+// @Failure 503 {object} ErrorResponse "Country catalog or signing key is not loaded yet"
 // @Router /countries [get]
 func CountriesListHandler(w http.ResponseWriter, r *http.Request) {
-	apiURL := "https://restcountries.com/v3.1/all"
+	fields, ok := fieldsFromRequest(w, r)
+	if !ok {
+		return
+	}
 
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching country data: %s", err))
+	format, ok := formatFromRequest(w, r, countryListFormats)
+	if !ok {
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching country data. Status code: %d", resp.StatusCode))
+	point, ok := pointFromRequest(w, r, format)
+	if !ok {
 		return
 	}
 
-	var countryData []map[string]interface{}
+	withBOM, ok := bomFromRequest(w, r, format)
+	if !ok {
+		return
+	}
 
-	err = json.NewDecoder(resp.Body).Decode(&countryData)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error decoding country data: %s", err))
+	countryData, ok := catalogCountries(w, r)
+	if !ok {
+		return
+	}
 
-		// Print the raw response body for debugging
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Fprintf(w, "Raw response body: %s", body)
+	if format == formatGeoJSON {
+		writeGeoJSONResponse(w, r, countryData, fields, point)
+		return
+	}
+
+	if format == formatProtobuf {
+		messages, err := protoCountries(countryData, fields)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeEncoded(w, formatProtobuf, http.StatusOK, &countrypb.CountryListResponse{Country: messages})
+		return
+	}
+
+	if format == formatCSV {
+		items, err := fields.applyCountries(countryData)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeCSVResponse(w, r, fields.csvColumns(), items, withBOM)
+		return
+	}
 
+	if fields != nil {
+		projected, err := fields.applyCountries(countryData)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeResponse(w, r, http.StatusOK, map[string]interface{}{"country": projected})
 		return
 	}
 
-	response := map[string]interface{}{"country": countryData}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	response := CountryListResponse{Country: countryData}
+	writeResponse(w, r, http.StatusOK, response)
 }
 
 // CountryDetailsHandler fetches detailed information about a specific country
 // CountryDetailsHandler godoc
 // @Summary Get detailed information about a specific country
-// @Description Get detailed information about a specific country
+// @Description Get detailed information about a specific country by name, or about several countries at once by ISO 3166 / IOC codes.
+// @Description Name matches are ranked best first and carry a score between 0 and 1.
 // @Tags countries
 // @Accept  json
 // @Produce  json
-// @Param name query string true "The name of the country to fetch."
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Produce  text/csv
+// @Produce  application/x-protobuf
+// @Param name query string false "The name of the country to fetch."
+// @Param match query string false "Name match mode: exact (common name), official (official name), prefix, substring or fuzzy (edit distance across names, translations and alt spellings)." Enums(exact, official, prefix, substring, fuzzy) default(substring)
+// @Param fields query string false "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element."
+// @Param codes query string false "Comma-separated cca2, cca3, ccn3 or cioc codes to fetch (e.g. US,FR,IN). Used when name is not provided."
+// @Param format query string false "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv or application/x-protobuf)." Enums(json, xml, yaml, csv, protobuf)
+// @Param bom query boolean false "With CSV output, prefix the body with a UTF-8 byte order mark for Excel."
 // @Security ApiKeyAuth
 // @Param Authorization header string true "JWT token"
-// @Success 200 {object} map[string]interface{} "country data"
-// @Failure 400 {object} ErrorResponse "Error: Please provide a valid country name in the query parameters"
-// @Failure 500 {object} ErrorResponse "Error fetching country data or decoding country data"
+// @Success 200 {object} CountryMatchesResponse "country data, ranked by match score"
+// @Failure 400 {object} ErrorResponse "Error: Please provide a valid country name or match mode in the query parameters"
+// @Failure 404 {object} ErrorResponse "No country matches the name, or unknown country codes"
+// @Failure 503 {object} ErrorResponse "Country catalog or signing key is not loaded yet"
 // @Router /country [get]
 func CountryDetailsHandler(w http.ResponseWriter, r *http.Request) {
 	// Extract country name from the query parameters
 	countryName := r.URL.Query().Get("name")
+	codes := r.URL.Query().Get("codes")
+
+	fields, ok := fieldsFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	format, ok := formatFromRequest(w, r, countryFormats)
+	if !ok {
+		return
+	}
+
+	withBOM, ok := bomFromRequest(w, r, format)
+	if !ok {
+		return
+	}
+
+	if countryName == "" && codes != "" {
+		countriesByCodesResponse(w, r, codes, fields, format, withBOM)
+		return
+	}
 
 	if countryName == "" {
-		writeJSONError(w, http.StatusBadRequest, "Error: Please provide a country name in the query parameters (e.g., /country?name=India)")
+		writeError(w, r, http.StatusBadRequest, "Error: Please provide a country name in the query parameters (e.g., /country?name=India)")
 		return
 	}
 
-	apiURL := fmt.Sprintf("https://restcountries.com/v3.1/name/%s", countryName)
+	matchMode := r.URL.Query().Get("match")
 
-	resp, err := http.Get(apiURL)
+	countries, ok := catalogCountries(w, r)
+	if !ok {
+		return
+	}
+
+	matches, err := matchCountries(countries, countryName, matchMode)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching country data: %s", err))
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(matches) == 0 {
+		writeError(w, r, http.StatusNotFound, fmt.Sprintf("No country matches %q", countryName))
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching country data. Status code: %d", resp.StatusCode))
+	if format == formatCSV {
+		items, err := fields.applyMatches(matches)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeCSVResponse(w, r, append(fields.csvColumns(), "score"), items, withBOM)
 		return
 	}
 
-	var countryData []map[string]interface{}
+	if format == formatProtobuf {
+		messages, err := protoMatches(matches, fields)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeEncoded(w, formatProtobuf, http.StatusOK, &countrypb.CountryMatchesResponse{CountryData: messages})
+		return
+	}
 
-	err = json.NewDecoder(resp.Body).Decode(&countryData)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error decoding country data: %s", err))
+	if fields != nil {
+		projected, err := fields.applyMatches(matches)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeResponse(w, r, http.StatusOK, map[string]interface{}{"country_data": projected})
+		return
+	}
+
+	// Create a response with the desired structure
+	response := CountryMatchesResponse{CountryData: matches}
+	writeResponse(w, r, http.StatusOK, response)
+}
+
+// CountryByCodeHandler fetches a country by its ISO 3166 or IOC code
+// CountryByCodeHandler godoc
+// @Summary Get a country by code
+// @Description Get a country by its exact ISO 3166-1 alpha-2 (cca2), alpha-3 (cca3), numeric (ccn3) or IOC (cioc) code, case-insensitively
+// @Tags countries
+// @Accept  json
+// @Produce  json
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Produce  text/csv
+// @Produce  application/x-protobuf
+// @Param code path string true "The cca2, cca3, ccn3 or cioc code of the country (e.g. IN, IND, 356)."
+// @Param fields query string false "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element."
+// @Param format query string false "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv or application/x-protobuf)." Enums(json, xml, yaml, csv, protobuf)
+// @Param bom query boolean false "With CSV output, prefix the body with a UTF-8 byte order mark for Excel."
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {object} CountryDetailsResponse "country data"
+// @Failure 400 {object} ErrorResponse "Invalid country code"
+// @Failure 404 {object} ErrorResponse "Unknown country code"
+// @Failure 503 {object} ErrorResponse "Country catalog or signing key is not loaded yet"
+// @Router /country/{code} [get]
+func CountryByCodeHandler(w http.ResponseWriter, r *http.Request) {
+	fields, ok := fieldsFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	format, ok := formatFromRequest(w, r, countryFormats)
+	if !ok {
+		return
+	}
+
+	withBOM, ok := bomFromRequest(w, r, format)
+	if !ok {
+		return
+	}
+
+	countriesByCodesResponse(w, r, mux.Vars(r)["code"], fields, format, withBOM)
+}
+
+// countriesByCodesResponse writes the countries for a comma-separated list of codes, in request order.
+// Any malformed code is a 400 and any unknown code is a 404.
+func countriesByCodesResponse(w http.ResponseWriter, r *http.Request, codeList string, fields fieldProjection, format string, withBOM bool) {
+	var codes []string
+	seen := make(map[string]bool)
+	for _, code := range strings.Split(codeList, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || seen[code] {
+			continue
+		}
+		if !isCountryCode(code) {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid country code %q: expected a cca2, cca3, ccn3 or cioc code", code))
+			return
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+
+	if len(codes) == 0 {
+		writeError(w, r, http.StatusBadRequest, "Error: Please provide at least one country code (e.g., /country?codes=US,FR,IN)")
+		return
+	}
+	if len(codes) > maxBatchCodes {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("Too many country codes: at most %d are allowed per request", maxBatchCodes))
+		return
+	}
+
+	var countryData []Country
+	var unknown []string
+	for _, code := range codes {
+		country, err := catalog.ByCode(code)
+		switch {
+		case errors.Is(err, errCatalogNotLoaded):
+			writeError(w, r, http.StatusServiceUnavailable, "Error: Country data is not available yet, please retry shortly")
+			return
+		case errors.Is(err, errCountryNotFound):
+			unknown = append(unknown, code)
+		default:
+			countryData = append(countryData, country)
+		}
+	}
 
-		// Print the raw response body for debugging
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Fprintf(w, "Raw response body: %s", body)
+	if len(unknown) > 0 {
+		writeError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown country code(s): %s", strings.Join(unknown, ", ")))
+		return
+	}
+
+	if format == formatCSV {
+		items, err := fields.applyCountries(countryData)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeCSVResponse(w, r, fields.csvColumns(), items, withBOM)
+		return
+	}
 
+	if format == formatProtobuf {
+		messages, err := protoCountries(countryData, fields)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeEncoded(w, formatProtobuf, http.StatusOK, &countrypb.CountryDetailsResponse{CountryData: messages})
 		return
 	}
 
-	// Create a map with the desired structure
-	responseMap := map[string]interface{}{"country_data": countryData}
+	if fields != nil {
+		projected, err := fields.applyCountries(countryData)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+			return
+		}
+		writeResponse(w, r, http.StatusOK, map[string]interface{}{"country_data": projected})
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, CountryDetailsResponse{CountryData: countryData})
+}
+
+// isCountryCode reports whether code looks like a cca2, cca3, cioc (letters) or ccn3 (digits) code.
+func isCountryCode(code string) bool {
+	if len(code) != 2 && len(code) != 3 {
+		return false
+	}
+
+	letters, digits := 0, 0
+	for _, ch := range code {
+		switch {
+		case ch >= 'A' && ch <= 'Z':
+			letters++
+		case ch >= '0' && ch <= '9':
+			digits++
+		}
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(responseMap)
+	return letters == len(code) || (digits == 3 && len(code) == 3)
 }
 
 // CountriesFilterListHandler retrieves a filtered and sorted list of countries based on specified parameters.
@@ -119,218 +359,285 @@ func CountryDetailsHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags countries
 // @Accept  json
 // @Produce  json
-// @Param population query integer false "Filter countries by population."
-// @Param area query integer false "Filter countries by area."
-// @Param language query string false "Filter countries by language."
-// @Param sort query string false "Sort order (asc or desc)."
+// @Produce  application/xml
+// @Produce  application/yaml
+// @Produce  text/csv
+// @Produce  application/x-protobuf
+// @Produce  application/geo+json
+// @Param population_min query number false "Minimum population (inclusive)."
+// @Param population_max query number false "Maximum population (inclusive)."
+// @Param area_min query number false "Minimum area in km² (inclusive)."
+// @Param area_max query number false "Maximum area in km² (inclusive)."
+// @Param density_min query number false "Minimum population density in people per km² (inclusive)."
+// @Param density_max query number false "Maximum population density in people per km² (inclusive)."
+// @Param population query number false "Deprecated: same as population_min."
+// @Param area query number false "Deprecated: same as area_min."
+// @Param language query []string false "Filter by language, as ISO 639-1 (hi) or ISO 639-3 (hin) codes or English names (Hindi), case-insensitive." collectionFormat(csv)
+// @Param language_match query string false "Whether countries must speak any or all of the listed languages." Enums(any, all) default(any)
+// @Param include_unknown_languages query boolean false "Also return countries without language data when filtering by language."
+// @Param region query []string false "Filter by region, e.g. Europe,Asia." collectionFormat(csv)
+// @Param subregion query []string false "Filter by subregion, e.g. Western Europe." collectionFormat(csv)
+// @Param continent query []string false "Filter by continent, e.g. South America." collectionFormat(csv)
+// @Param currency query []string false "Filter by ISO 4217 currency code, e.g. EUR,USD." collectionFormat(csv)
+// @Param timezone query []string false "Filter by timezone, e.g. UTC+05:30." collectionFormat(csv)
+// @Param calling_code query []string false "Filter by international calling code, e.g. +91 or 44." collectionFormat(csv)
+// @Param landlocked query boolean false "Filter landlocked (true) or coastal (false) countries."
+// @Param independent query boolean false "Filter independent (true) or dependent (false) territories."
+// @Param un_member query boolean false "Filter UN members (true) or non-members (false)."
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending order: name, official_name, region, capital, population, area, density or gini (e.g. region,-population). Ties are broken by cca3. The legacy values asc and desc sort by name."
 // @Param page query integer false "Page number for pagination."
+// @Param limit query integer false "Number of countries per page, at most 100." default(20)
+// @Param fields query string false "Comma-separated dotted field paths to return for each country instead of its common name, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element."
+// @Param cursor query string false "Opaque cursor from a previous response's next_cursor. Continues after the last country of that response; cannot be combined with page."
+// @Param format query string false "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv, application/geo+json or application/x-protobuf)." Enums(json, xml, yaml, csv, geojson, protobuf)
+// @Param point query string false "With GeoJSON output, place each feature at the country centroid (latlng) or its capital (capitalInfo.latlng)." Enums(centroid, capital) default(centroid)
+// @Param bom query boolean false "With CSV output, prefix the body with a UTF-8 byte order mark for Excel."
 // @Security ApiKeyAuth
 // @Param Authorization header string true "JWT token"
-// @Success 200 {object} map[string][]string "paginated list of countries"
-// @Failure 400 {object} ErrorResponse "Invalid filter or page parameters"
-// @Failure 500 {object} ErrorResponse "Error fetching or decoding countries data"
+// @Success 200 {object} CountryFilterResponse "paginated list of countries"
+// @Header 200 {string} Link "RFC 8288 links to the first, prev, next and last pages"
+// @Header 200 {integer} X-Total-Count "Total number of matching countries (CSV responses)"
+// @Header 200 {string} X-Next-Cursor "Cursor for the next page, when there is one (CSV responses)"
+// @Failure 400 {object} ErrorResponse "Invalid filter or page parameters, a range whose min is greater than its max, or an invalid, tampered or expired cursor"
+// @Failure 503 {object} ErrorResponse "Country catalog or signing key is not loaded yet"
 // @Router /countries/filter [get]
 func CountriesFilterListHandler(w http.ResponseWriter, r *http.Request) {
-	// Extract filter parameters from query
-	populationFilterStr := r.URL.Query().Get("population")
-	areaFilterStr := r.URL.Query().Get("area")
-	languageFilter := r.URL.Query().Get("language")
-	sortOrder := r.URL.Query().Get("sort") // "asc" or "desc"
-	pageStr := r.URL.Query().Get("page")
-
-	// Parse filter parameters
-	var populationFilter, areaFilter, page int
-	var err error
-
-	if populationFilterStr != "" {
-		populationFilter, err = strconv.Atoi(populationFilterStr)
-		if err != nil {
-			// w.WriteHeader(http.StatusBadRequest)
-			// fmt.Fprint(w, "Error: Invalid population filter value")
-			// return
-			writeJSONError(w, http.StatusBadRequest, "Invalid population filter value")
-			return
+	// Extract filter, sort and page parameters from query
+	query, err := parseCountryFilterQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fields, ok := fieldsFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	format, ok := formatFromRequest(w, r, countryListFormats)
+	if !ok {
+		return
+	}
+
+	point, ok := pointFromRequest(w, r, format)
+	if !ok {
+		return
+	}
+
+	withBOM, ok := bomFromRequest(w, r, format)
+	if !ok {
+		return
+	}
+
+	// Without fields the filter endpoint lists common names, so CSV gets a single name column
+	if format == formatCSV && fields == nil {
+		fields = fieldProjection{{"name", "common"}}
+	}
+
+	// Read all countries from the in-memory catalog
+	countriesData, ok := catalogCountries(w, r)
+	if !ok {
+		return
+	}
+
+	// Apply filters and sorting, and cut out the requested page
+	result, err := query.run(countriesData)
+	if errors.Is(err, errNoSigningKey) {
+		writeError(w, r, http.StatusServiceUnavailable, "Error: No signing key is loaded, please retry shortly")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "Error generating pagination cursor")
+		return
+	}
+
+	page := result.page
+	links := page.links(r)
+	if query.cursor != nil {
+		links = map[string]string{"first": pageURL(r, 1, page.Size)}
+		if result.nextCursor != "" {
+			links["next"] = cursorURL(r, result.nextCursor, page.Size)
 		}
 	}
 
-	if areaFilterStr != "" {
-		areaFilter, err = strconv.Atoi(areaFilterStr)
+	if format == formatGeoJSON {
+		writeLinkHeader(w, links)
+		w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+		if result.nextCursor != "" {
+			w.Header().Set("X-Next-Cursor", result.nextCursor)
+		}
+		writeGeoJSONResponse(w, r, result.countries, fields, point)
+		return
+	}
+
+	if format == formatProtobuf {
+		writeLinkHeader(w, links)
+		response, err := result.proto(fields)
 		if err != nil {
-			// w.WriteHeader(http.StatusBadRequest)
-			// fmt.Fprint(w, "Error: Invalid area filter value")
-			// return
-			writeJSONError(w, http.StatusBadRequest, "Invalid area filter value")
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
 			return
 		}
+		response.Next = links["next"]
+		response.Prev = links["prev"]
+		writeEncoded(w, formatProtobuf, http.StatusOK, response)
+		return
 	}
 
-	if pageStr != "" {
-		page, err = strconv.Atoi(pageStr)
-		if err != nil || page <= 0 {
-			// w.WriteHeader(http.StatusBadRequest)
-			// fmt.Fprint(w, "Error: Invalid page number")
-			// return
-			writeJSONError(w, http.StatusBadRequest, "Invalid page number")
+	// Extract country names, or the requested fields of each country
+	countryItems := []interface{}{}
+	if fields != nil {
+		countryItems, err = fields.applyCountries(result.countries)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
 			return
 		}
 	} else {
-		// Set default page value if not present
-		page = 1
+		for _, country := range result.countries {
+			countryItems = append(countryItems, country.Name.Common)
+		}
 	}
 
-	// Fetch all countries from the REST Countries API
-	apiURL := "https://restcountries.com/v3.1/all"
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		// w.WriteHeader(http.StatusInternalServerError)
-		// fmt.Fprintf(w, "Error fetching countries data: %s", err)
-		// return
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching countries data: %s", err))
+	writeLinkHeader(w, links)
+
+	if format == formatCSV {
+		w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+		if result.nextCursor != "" {
+			w.Header().Set("X-Next-Cursor", result.nextCursor)
+		}
+		writeCSVResponse(w, r, fields.csvColumns(), countryItems, withBOM)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		// w.WriteHeader(http.StatusInternalServerError)
-		// fmt.Fprintf(w, "Error fetching countries data. Status code: %d", resp.StatusCode)
-		// return
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error fetching countries data. Status code: %d", resp.StatusCode))
-		return
+	response := CountryFilterResponse{
+		Country:    countryItems,
+		Total:      page.Total,
+		Page:       page.Page,
+		PageSize:   page.Size,
+		TotalPages: page.TotalPages,
+		Next:       links["next"],
+		Prev:       links["prev"],
+		NextCursor: result.nextCursor,
 	}
+	writeResponse(w, r, http.StatusOK, response)
+}
 
-	var countriesData []map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&countriesData)
+// countryFilterQuery is a parsed filter request: the filter, the sort order and the page or cursor to return.
+type countryFilterQuery struct {
+	values   url.Values
+	filter   CountryFilter
+	sortKeys []sortKey
+	page     pageRequest
+	cursor   *pageCursor
+}
+
+// parseCountryFilterQuery parses the filter, sort, page, limit and cursor parameters of a filter request.
+func parseCountryFilterQuery(values url.Values) (countryFilterQuery, error) {
+	filter, err := parseCountryFilter(values)
 	if err != nil {
-		// w.WriteHeader(http.StatusInternalServerError)
-		// fmt.Fprintf(w, "Error decoding countries data: %s\n", err)
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Error decoding countries data: %s", err))
+		return countryFilterQuery{}, err
+	}
 
-		// Print the raw response body for debugging
-		body, _ := io.ReadAll(resp.Body)
-		// fmt.Fprintf(w, "Raw response body: %s", body)
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("Raw response body: %s", body))
+	sortKeys, err := parseSortKeys(values.Get("sort"))
+	if err != nil {
+		return countryFilterQuery{}, err
+	}
 
-		return
+	pageReq, err := parsePageRequest(values)
+	if err != nil {
+		return countryFilterQuery{}, err
 	}
 
-	// Apply filters and sorting
-	filteredCountries := filterAndSortCountries(countriesData, populationFilter, areaFilter, languageFilter, sortOrder)
+	query := countryFilterQuery{values: values, filter: filter, sortKeys: sortKeys, page: pageReq}
 
-	// Paginate the results
-	startIndex := (page - 1) * 20
-	// Ensure startIndex is within bounds
-	if startIndex >= len(filteredCountries) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string][]string{"country": {}}) // Empty response for out-of-bounds page
-		return
+	if cursorToken := values.Get("cursor"); cursorToken != "" {
+		if values.Get("page") != "" {
+			return countryFilterQuery{}, errors.New("Use either page or cursor, not both")
+		}
+		cursor, err := decodePageCursor(cursorToken, values, sortKeys)
+		if err != nil {
+			return countryFilterQuery{}, err
+		}
+		query.cursor = &cursor
 	}
 
-	endIndex := startIndex + 20
+	return query, nil
+}
+
+// countryFilterResult is one page of filtered countries.
+type countryFilterResult struct {
+	page       pageInfo
+	countries  []Country
+	nextCursor string
+}
 
-	// Ensure endIndex is within bounds
-	if endIndex > len(filteredCountries) {
-		endIndex = len(filteredCountries)
+// run filters and sorts countries and cuts out the requested page. An out-of-bounds page yields
+// an empty list. A cursor continues right after the last country it saw, even if the dataset
+// changed since; its page number is reported as 0.
+func (q countryFilterQuery) run(countries []Country) (countryFilterResult, error) {
+	filteredCountries := filterAndSortCountries(countries, q.filter, q.sortKeys)
+
+	page := newPageInfo(q.page, len(filteredCountries))
+	startIndex, endIndex := page.bounds()
+
+	if q.cursor != nil {
+		page.Page = 0
+		startIndex = q.cursor.after(filteredCountries, q.sortKeys)
+		endIndex = startIndex + page.Size
+		if endIndex > len(filteredCountries) {
+			endIndex = len(filteredCountries)
+		}
 	}
 
-	// Extract country names
-	var countryNames []string
-	for _, country := range filteredCountries[startIndex:endIndex] {
-		name, ok := country["name"].(map[string]interface{})["common"].(string)
-		if ok {
-			countryNames = append(countryNames, name)
+	result := countryFilterResult{page: page, countries: filteredCountries[startIndex:endIndex]}
+	if endIndex > startIndex && endIndex < len(filteredCountries) {
+		nextCursor, err := newPageCursor(q.values, q.sortKeys, filteredCountries[endIndex-1]).encode()
+		if err != nil {
+			return countryFilterResult{}, err
 		}
+		result.nextCursor = nextCursor
 	}
 
-	response := map[string][]string{"country": countryNames}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	return result, nil
 }
 
-func writeJSONError(w http.ResponseWriter, status int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
+// proto returns the page as a protobuf message, with common names or the projected countries.
+func (res countryFilterResult) proto(fields fieldProjection) (*countrypb.CountryFilterResponse, error) {
+	response := &countrypb.CountryFilterResponse{
+		Total:      int32(res.page.Total),
+		Page:       int32(res.page.Page),
+		PageSize:   int32(res.page.Size),
+		TotalPages: int32(res.page.TotalPages),
+		NextCursor: res.nextCursor,
+	}
 
-	errorResponse := ErrorResponse{
-		Error: message,
+	if fields == nil {
+		for _, country := range res.countries {
+			response.Country = append(response.Country, country.Name.Common)
+		}
+		return response, nil
 	}
 
-	json.NewEncoder(w).Encode(errorResponse)
+	countries, err := protoCountries(res.countries, fields)
+	if err != nil {
+		return nil, err
+	}
+	response.Countries = countries
+	return response, nil
 }
 
 // filterAndSortCountries filters and sorts the countries based on the specified parameters
-func filterAndSortCountries(countries []map[string]interface{}, populationFilter, areaFilter int, languageFilter, sortOrder string) []map[string]interface{} {
-	var filteredCountries []map[string]interface{}
+func filterAndSortCountries(countries []Country, filter CountryFilter, sortKeys []sortKey) []Country {
+	var filteredCountries []Country
 
 	for _, country := range countries {
-		population, popOk := country["population"].(float64)
-		area, areaOk := country["area"].(float64)
-		languages, langOk := country["languages"].(map[string]interface{})
-
-		// Apply filters
-		populationCondition := !popOk || (populationFilter <= 0 || int(population) >= populationFilter)
-		areaCondition := !areaOk || (areaFilter <= 0 || int(area) == areaFilter)
-		languageCondition := !langOk || (languageFilter == "" || languages[languageFilter] != nil)
-
 		// Include the country in filteredCountries only if all conditions are met
-		if populationCondition && areaCondition && languageCondition {
+		if filter.Match(country) {
 			filteredCountries = append(filteredCountries, country)
 		}
 	}
 
-	// Sort the filtered countries based on population in ascending or descending order
-	if sortOrder == "desc" {
-		sortCountriesDesc(filteredCountries)
-	} else {
-		sortCountriesAsc(filteredCountries)
-	}
+	// Sort the filtered countries by the requested keys
+	sortCountries(filteredCountries, sortKeys)
 
 	return filteredCountries
 }
-
-// sortCountriesAsc sorts countries based on population in ascending order
-// func sortCountriesAsc(countries []map[string]interface{}) {
-// 	for i := 0; i < len(countries)-1; i++ {
-// 		for j := i + 1; j < len(countries); j++ {
-// 			population1, _ := countries[i]["population"].(float64)
-// 			population2, _ := countries[j]["population"].(float64)
-
-// 			if population1 > population2 {
-// 				// Swap countries
-// 				countries[i], countries[j] = countries[j], countries[i]
-// 			}
-// 		}
-// 	}
-// }
-
-// // sortCountriesDesc sorts countries based on population in descending order
-// func sortCountriesDesc(countries []map[string]interface{}) {
-// 	for i := 0; i < len(countries)-1; i++ {
-// 		for j := i + 1; j < len(countries); j++ {
-// 			population1, _ := countries[i]["population"].(float64)
-// 			population2, _ := countries[j]["population"].(float64)
-
-// 			if population1 < population2 {
-// 				// Swap countries
-// 				countries[i], countries[j] = countries[j], countries[i]
-// 			}
-// 		}
-// 	}
-// }
-
-// sortCountriesAsc sorts countries based on Name in ascending order
-func sortCountriesAsc(countries []map[string]interface{}) {
-	sort.Slice(countries, func(i, j int) bool {
-		name1, _ := countries[i]["name"].(map[string]interface{})["common"].(string)
-		name2, _ := countries[j]["name"].(map[string]interface{})["common"].(string)
-		return strings.ToLower(name1) < strings.ToLower(name2)
-	})
-}
-
-// sortCountriesDesc sorts countries based on Name in descending order
-func sortCountriesDesc(countries []map[string]interface{}) {
-	sort.Slice(countries, func(i, j int) bool {
-		name1, _ := countries[i]["name"].(map[string]interface{})["common"].(string)
-		name2, _ := countries[j]["name"].(map[string]interface{})["common"].(string)
-		return strings.ToLower(name1) > strings.ToLower(name2)
-	})
-}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestCountryCodeLookups checks the /country?codes= batch lookup and the /country/{code} route.
+func TestCountryCodeLookups(t *testing.T) {
+	useCatalog(t, []Country{
+		{Name: CountryName{Common: "France"}, CCA2: "FR", CCA3: "FRA", CCN3: "250", CIOC: "FRA"},
+		{Name: CountryName{Common: "India"}, CCA2: "IN", CCA3: "IND", CCN3: "356", CIOC: "IND"},
+		{Name: CountryName{Common: "United States"}, CCA2: "US", CCA3: "USA", CCN3: "840", CIOC: "USA"},
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		code   string
+		status int
+		want   []string
+	}{
+		{"batch in request order", "/country?codes=US,fr,356", "", http.StatusOK, []string{"USA", "FRA", "IND"}},
+		{"duplicates and blanks", "/country?codes=IN,,ind,%20in%20", "", http.StatusOK, []string{"IND", "IND"}},
+		{"unknown code", "/country?codes=US,XX", "", http.StatusNotFound, nil},
+		{"malformed code", "/country?codes=US,U1", "", http.StatusBadRequest, nil},
+		{"only separators", "/country?codes=,,", "", http.StatusBadRequest, nil},
+		{"path cca3", "/country/usa", "usa", http.StatusOK, []string{"USA"}},
+		{"path ccn3", "/country/250", "250", http.StatusOK, []string{"FRA"}},
+		{"path too long", "/country/FRANCE", "FRANCE", http.StatusBadRequest, nil},
+		{"path unknown", "/country/ZZ", "ZZ", http.StatusNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			if tt.code != "" {
+				CountryByCodeHandler(rec, mux.SetURLVars(req, map[string]string{"code": tt.code}))
+			} else {
+				CountryDetailsHandler(rec, req)
+			}
+
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var response CountryDetailsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			if got := countryCodes(response.CountryData); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// Country is the typed representation of a restcountries v3.1 country entry.
+type Country struct {
+	Name         CountryName           `json:"name"`
+	TLD          StringList            `json:"tld,omitempty"`
+	CCA2         string                `json:"cca2"`
+	CCN3         string                `json:"ccn3,omitempty"`
+	CCA3         string                `json:"cca3"`
+	CIOC         string                `json:"cioc,omitempty"`
+	Independent  *bool                 `json:"independent,omitempty"`
+	Status       string                `json:"status,omitempty"`
+	UNMember     bool                  `json:"unMember"`
+	Currencies   map[string]Currency   `json:"currencies,omitempty"`
+	IDD          IDD                   `json:"idd"`
+	Capital      StringList            `json:"capital,omitempty"`
+	AltSpellings StringList            `json:"altSpellings,omitempty"`
+	Region       string                `json:"region"`
+	Subregion    string                `json:"subregion,omitempty"`
+	Languages    map[string]string     `json:"languages,omitempty"`
+	Translations map[string]NativeName `json:"translations,omitempty"`
+	LatLng       []float64             `json:"latlng,omitempty"`
+	Landlocked   bool                  `json:"landlocked"`
+	Borders      StringList            `json:"borders,omitempty"`
+	Area         float64               `json:"area"`
+	Demonyms     map[string]Demonym    `json:"demonyms,omitempty"`
+	Flag         string                `json:"flag,omitempty"`
+	Maps         Maps                  `json:"maps"`
+	Population   int64                 `json:"population"`
+	Gini         map[string]float64    `json:"gini,omitempty"`
+	FIFA         string                `json:"fifa,omitempty"`
+	Car          Car                   `json:"car"`
+	Timezones    StringList            `json:"timezones,omitempty"`
+	Continents   StringList            `json:"continents,omitempty"`
+	Flags        Images                `json:"flags"`
+	CoatOfArms   Images                `json:"coatOfArms"`
+	StartOfWeek  string                `json:"startOfWeek,omitempty"`
+	CapitalInfo  CapitalInfo           `json:"capitalInfo"`
+	PostalCode   *PostalCode           `json:"postalCode,omitempty"`
+}
+
+// CountryName holds the common, official and native names of a country.
+type CountryName struct {
+	Common     string                `json:"common"`
+	Official   string                `json:"official"`
+	NativeName map[string]NativeName `json:"nativeName,omitempty"`
+}
+
+// NativeName is a common/official name pair in a given language.
+type NativeName struct {
+	Official string `json:"official"`
+	Common   string `json:"common"`
+}
+
+// Currency describes a currency used by a country, keyed by ISO 4217 code.
+type Currency struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol,omitempty"`
+}
+
+// IDD holds the international direct dialing root and suffixes.
+type IDD struct {
+	Root     string     `json:"root,omitempty"`
+	Suffixes StringList `json:"suffixes,omitempty"`
+}
+
+// Demonym holds the female and male demonyms in a given language.
+type Demonym struct {
+	F string `json:"f"`
+	M string `json:"m"`
+}
+
+// Maps holds links to the country on external map services.
+type Maps struct {
+	GoogleMaps     string `json:"googleMaps,omitempty"`
+	OpenStreetMaps string `json:"openStreetMaps,omitempty"`
+}
+
+// Car holds the driving side and international vehicle registration codes.
+type Car struct {
+	Signs StringList `json:"signs,omitempty"`
+	Side  string     `json:"side,omitempty"`
+}
+
+// Images holds PNG/SVG image links such as flags or coats of arms.
+type Images struct {
+	PNG string `json:"png,omitempty"`
+	SVG string `json:"svg,omitempty"`
+	Alt string `json:"alt,omitempty"`
+}
+
+// CapitalInfo holds the coordinates of the capital city.
+type CapitalInfo struct {
+	LatLng []float64 `json:"latlng,omitempty"`
+}
+
+// PostalCode holds the postal code format and validation regex.
+type PostalCode struct {
+	Format string `json:"format"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+// HasCode reports whether code equals the country's cca2, cca3, ccn3 or cioc code, case-insensitively.
+func (c Country) HasCode(code string) bool {
+	if code == "" {
+		return false
+	}
+
+	return strings.EqualFold(c.CCA2, code) ||
+		strings.EqualFold(c.CCA3, code) ||
+		c.CCN3 == code ||
+		strings.EqualFold(c.CIOC, code)
+}
+
+// Density returns the population per square kilometre, or false if the area is unknown.
+func (c Country) Density() (float64, bool) {
+	if c.Area <= 0 {
+		return 0, false
+	}
+
+	return float64(c.Population) / c.Area, true
+}
+
+// LatestGini returns the Gini coefficient of the most recent year, or false if none is known.
+func (c Country) LatestGini() (float64, bool) {
+	latestYear := ""
+	for year := range c.Gini {
+		if year > latestYear {
+			latestYear = year
+		}
+	}
+	if latestYear == "" {
+		return 0, false
+	}
+
+	return c.Gini[latestYear], true
+}
+
+// CallingCodes returns the international calling codes of the country. A single suffix completes
+// the root (e.g. +4 and 9 give +49 only), while a root shared by several suffixes is a code of
+// its own as well (e.g. +1, +1201 and +1202). A root without suffixes is returned as is.
+func (c Country) CallingCodes() []string {
+	if c.IDD.Root == "" {
+		return nil
+	}
+
+	var codes []string
+	for _, suffix := range c.IDD.Suffixes {
+		if suffix != "" {
+			codes = append(codes, c.IDD.Root+suffix)
+		}
+	}
+	if len(codes) == 1 {
+		return codes
+	}
+	return append([]string{c.IDD.Root}, codes...)
+}
+
+// StringList is a list of strings that also accepts a single JSON string or null.
+type StringList []string
+
+// UnmarshalJSON accepts either a JSON array or a bare string. Non-string
+// elements are ignored instead of failing the surrounding country.
+func (s *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single == "" {
+			*s = nil
+		} else {
+			*s = StringList{single}
+		}
+		return nil
+	}
+
+	var items []interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		*s = nil
+		return nil
+	}
+
+	list := make(StringList, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			list = append(list, str)
+		}
+	}
+	*s = list
+	return nil
+}
+
+// decodeCountries decodes a restcountries v3.1 JSON array into typed countries.
+// Fields with unexpected types are skipped rather than failing the whole entry,
+// and entries that cannot be decoded at all are dropped with a log line.
+func decodeCountries(r io.Reader) ([]Country, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	countries := make([]Country, 0, len(raw))
+	for i, entry := range raw {
+		country, err := decodeCountry(entry)
+		if err != nil {
+			log.Printf("Skipping country entry %d: %s", i, err)
+			continue
+		}
+		countries = append(countries, country)
+	}
+
+	return countries, nil
+}
+
+// decodeCountry decodes a single country entry, tolerating mistyped fields.
+func decodeCountry(data []byte) (Country, error) {
+	var country Country
+	err := json.Unmarshal(data, &country)
+
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return Country{}, err
+	}
+	if country.CCA3 == "" && country.Name.Common == "" {
+		return Country{}, fmt.Errorf("entry has neither a cca3 code nor a common name")
+	}
+
+	return country, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestStringListUnmarshalJSON checks that a StringList accepts arrays, bare strings and null, and
+// drops elements that are not strings.
+func TestStringListUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want StringList
+	}{
+		{"array", `["Oslo", "Bergen"]`, StringList{"Oslo", "Bergen"}},
+		{"empty array", `[]`, StringList{}},
+		{"bare string", `"Oslo"`, StringList{"Oslo"}},
+		{"empty string", `""`, nil},
+		{"null", `null`, nil},
+		{"mixed elements", `["Oslo", 1, null, {"a": "b"}, "Bergen"]`, StringList{"Oslo", "Bergen"}},
+		{"number", `42`, nil},
+		{"object", `{"capital": "Oslo"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StringList{"stale"}
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDecodeCountriesToleratesUnexpectedValues checks that unknown fields and mistyped values
+// do not fail an entry, and that only entries without any identity are dropped.
+func TestDecodeCountriesToleratesUnexpectedValues(t *testing.T) {
+	const data = `[
+		{"name": {"common": "Norway", "official": "Kingdom of Norway"}, "cca2": "NO", "cca3": "NOR",
+		 "capital": "Oslo", "population": "five million", "landlocked": false, "newField": {"x": 1}},
+		{"name": {"common": "Sweden"}, "cca3": "SWE", "area": 450295, "borders": ["NOR", 7, "FIN"],
+		 "latlng": [62, "15"]},
+		{"region": "Europe"},
+		"not a country"
+	]`
+
+	countries, err := decodeCountries(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if len(countries) != 2 {
+		t.Fatalf("decoded %d countries, want 2: %+v", len(countries), countries)
+	}
+
+	norway, sweden := countries[0], countries[1]
+	if norway.Name.Official != "Kingdom of Norway" || norway.CCA2 != "NO" {
+		t.Errorf("Norway lost fields around the mistyped population: %+v", norway)
+	}
+	if !reflect.DeepEqual(norway.Capital, StringList{"Oslo"}) {
+		t.Errorf("Norway capital: got %#v", norway.Capital)
+	}
+	if norway.Population != 0 {
+		t.Errorf("Norway population: got %d, want 0 for a mistyped value", norway.Population)
+	}
+	if sweden.Area != 450295 || !reflect.DeepEqual(sweden.Borders, StringList{"NOR", "FIN"}) {
+		t.Errorf("Sweden: got area %v borders %#v", sweden.Area, sweden.Borders)
+	}
+}
+
+// TestDecodeCountriesRejectsInvalidJSON checks that a body that is not a JSON array fails.
+func TestDecodeCountriesRejectsInvalidJSON(t *testing.T) {
+	for _, data := range []string{``, `{"cca3": "NOR"}`, `[{"cca3": "NOR"}`} {
+		if _, err := decodeCountries(strings.NewReader(data)); err == nil {
+			t.Errorf("decoding %q succeeded", data)
+		}
+	}
+}
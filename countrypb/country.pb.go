@@ -0,0 +1,1722 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
+// source: country.proto
+
+// Country data served by the Country API, mirroring the restcountries v3.1 JSON form.
+// Field names map to the same JSON names as the HTTP API (e.g. alt_spellings <-> altSpellings).
+
+package countrypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Country is a restcountries v3.1 country entry.
+type Country struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name *CountryName `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tld  []string     `protobuf:"bytes,2,rep,name=tld,proto3" json:"tld,omitempty"`
+	Cca2 string       `protobuf:"bytes,3,opt,name=cca2,proto3" json:"cca2,omitempty"`
+	Ccn3 string       `protobuf:"bytes,4,opt,name=ccn3,proto3" json:"ccn3,omitempty"`
+	Cca3 string       `protobuf:"bytes,5,opt,name=cca3,proto3" json:"cca3,omitempty"`
+	Cioc string       `protobuf:"bytes,6,opt,name=cioc,proto3" json:"cioc,omitempty"`
+	// Unset when the source does not say, e.g. for Kosovo.
+	Independent *bool  `protobuf:"varint,7,opt,name=independent,proto3,oneof" json:"independent,omitempty"`
+	Status      string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	UnMember    bool   `protobuf:"varint,9,opt,name=un_member,json=unMember,proto3" json:"un_member,omitempty"`
+	// Currencies keyed by ISO 4217 code.
+	Currencies   map[string]*Currency `protobuf:"bytes,10,rep,name=currencies,proto3" json:"currencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Idd          *Idd                 `protobuf:"bytes,11,opt,name=idd,proto3" json:"idd,omitempty"`
+	Capital      []string             `protobuf:"bytes,12,rep,name=capital,proto3" json:"capital,omitempty"`
+	AltSpellings []string             `protobuf:"bytes,13,rep,name=alt_spellings,json=altSpellings,proto3" json:"alt_spellings,omitempty"`
+	Region       string               `protobuf:"bytes,14,opt,name=region,proto3" json:"region,omitempty"`
+	Subregion    string               `protobuf:"bytes,15,opt,name=subregion,proto3" json:"subregion,omitempty"`
+	// Language names keyed by ISO 639-3 code.
+	Languages    map[string]string      `protobuf:"bytes,16,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Translations map[string]*NativeName `protobuf:"bytes,17,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Latitude and longitude of the country centroid.
+	Latlng     []float64 `protobuf:"fixed64,18,rep,packed,name=latlng,proto3" json:"latlng,omitempty"`
+	Landlocked bool      `protobuf:"varint,19,opt,name=landlocked,proto3" json:"landlocked,omitempty"`
+	// cca3 codes of the bordering countries.
+	Borders []string `protobuf:"bytes,20,rep,name=borders,proto3" json:"borders,omitempty"`
+	// Area in km².
+	Area       float64             `protobuf:"fixed64,21,opt,name=area,proto3" json:"area,omitempty"`
+	Demonyms   map[string]*Demonym `protobuf:"bytes,22,rep,name=demonyms,proto3" json:"demonyms,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Flag       string              `protobuf:"bytes,23,opt,name=flag,proto3" json:"flag,omitempty"`
+	Maps       *Maps               `protobuf:"bytes,24,opt,name=maps,proto3" json:"maps,omitempty"`
+	Population int64               `protobuf:"varint,25,opt,name=population,proto3" json:"population,omitempty"`
+	// Gini coefficients keyed by year.
+	Gini        map[string]float64 `protobuf:"bytes,26,rep,name=gini,proto3" json:"gini,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	Fifa        string             `protobuf:"bytes,27,opt,name=fifa,proto3" json:"fifa,omitempty"`
+	Car         *Car               `protobuf:"bytes,28,opt,name=car,proto3" json:"car,omitempty"`
+	Timezones   []string           `protobuf:"bytes,29,rep,name=timezones,proto3" json:"timezones,omitempty"`
+	Continents  []string           `protobuf:"bytes,30,rep,name=continents,proto3" json:"continents,omitempty"`
+	Flags       *Images            `protobuf:"bytes,31,opt,name=flags,proto3" json:"flags,omitempty"`
+	CoatOfArms  *Images            `protobuf:"bytes,32,opt,name=coat_of_arms,json=coatOfArms,proto3" json:"coat_of_arms,omitempty"`
+	StartOfWeek string             `protobuf:"bytes,33,opt,name=start_of_week,json=startOfWeek,proto3" json:"start_of_week,omitempty"`
+	CapitalInfo *CapitalInfo       `protobuf:"bytes,34,opt,name=capital_info,json=capitalInfo,proto3" json:"capital_info,omitempty"`
+	// Unset when the country has no postal codes.
+	PostalCode *PostalCode `protobuf:"bytes,35,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Country) GetName() *CountryName {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *Country) GetTld() []string {
+	if x != nil {
+		return x.Tld
+	}
+	return nil
+}
+
+func (x *Country) GetCca2() string {
+	if x != nil {
+		return x.Cca2
+	}
+	return ""
+}
+
+func (x *Country) GetCcn3() string {
+	if x != nil {
+		return x.Ccn3
+	}
+	return ""
+}
+
+func (x *Country) GetCca3() string {
+	if x != nil {
+		return x.Cca3
+	}
+	return ""
+}
+
+func (x *Country) GetCioc() string {
+	if x != nil {
+		return x.Cioc
+	}
+	return ""
+}
+
+func (x *Country) GetIndependent() bool {
+	if x != nil && x.Independent != nil {
+		return *x.Independent
+	}
+	return false
+}
+
+func (x *Country) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Country) GetUnMember() bool {
+	if x != nil {
+		return x.UnMember
+	}
+	return false
+}
+
+func (x *Country) GetCurrencies() map[string]*Currency {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *Country) GetIdd() *Idd {
+	if x != nil {
+		return x.Idd
+	}
+	return nil
+}
+
+func (x *Country) GetCapital() []string {
+	if x != nil {
+		return x.Capital
+	}
+	return nil
+}
+
+func (x *Country) GetAltSpellings() []string {
+	if x != nil {
+		return x.AltSpellings
+	}
+	return nil
+}
+
+func (x *Country) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Country) GetSubregion() string {
+	if x != nil {
+		return x.Subregion
+	}
+	return ""
+}
+
+func (x *Country) GetLanguages() map[string]string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *Country) GetTranslations() map[string]*NativeName {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *Country) GetLatlng() []float64 {
+	if x != nil {
+		return x.Latlng
+	}
+	return nil
+}
+
+func (x *Country) GetLandlocked() bool {
+	if x != nil {
+		return x.Landlocked
+	}
+	return false
+}
+
+func (x *Country) GetBorders() []string {
+	if x != nil {
+		return x.Borders
+	}
+	return nil
+}
+
+func (x *Country) GetArea() float64 {
+	if x != nil {
+		return x.Area
+	}
+	return 0
+}
+
+func (x *Country) GetDemonyms() map[string]*Demonym {
+	if x != nil {
+		return x.Demonyms
+	}
+	return nil
+}
+
+func (x *Country) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *Country) GetMaps() *Maps {
+	if x != nil {
+		return x.Maps
+	}
+	return nil
+}
+
+func (x *Country) GetPopulation() int64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *Country) GetGini() map[string]float64 {
+	if x != nil {
+		return x.Gini
+	}
+	return nil
+}
+
+func (x *Country) GetFifa() string {
+	if x != nil {
+		return x.Fifa
+	}
+	return ""
+}
+
+func (x *Country) GetCar() *Car {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+func (x *Country) GetTimezones() []string {
+	if x != nil {
+		return x.Timezones
+	}
+	return nil
+}
+
+func (x *Country) GetContinents() []string {
+	if x != nil {
+		return x.Continents
+	}
+	return nil
+}
+
+func (x *Country) GetFlags() *Images {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *Country) GetCoatOfArms() *Images {
+	if x != nil {
+		return x.CoatOfArms
+	}
+	return nil
+}
+
+func (x *Country) GetStartOfWeek() string {
+	if x != nil {
+		return x.StartOfWeek
+	}
+	return ""
+}
+
+func (x *Country) GetCapitalInfo() *CapitalInfo {
+	if x != nil {
+		return x.CapitalInfo
+	}
+	return nil
+}
+
+func (x *Country) GetPostalCode() *PostalCode {
+	if x != nil {
+		return x.PostalCode
+	}
+	return nil
+}
+
+// CountryName holds the common, official and native names of a country.
+type CountryName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Common   string `protobuf:"bytes,1,opt,name=common,proto3" json:"common,omitempty"`
+	Official string `protobuf:"bytes,2,opt,name=official,proto3" json:"official,omitempty"`
+	// Native names keyed by ISO 639-3 code.
+	NativeName map[string]*NativeName `protobuf:"bytes,3,rep,name=native_name,json=nativeName,proto3" json:"native_name,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CountryName) Reset() {
+	*x = CountryName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryName) ProtoMessage() {}
+
+func (x *CountryName) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryName.ProtoReflect.Descriptor instead.
+func (*CountryName) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CountryName) GetCommon() string {
+	if x != nil {
+		return x.Common
+	}
+	return ""
+}
+
+func (x *CountryName) GetOfficial() string {
+	if x != nil {
+		return x.Official
+	}
+	return ""
+}
+
+func (x *CountryName) GetNativeName() map[string]*NativeName {
+	if x != nil {
+		return x.NativeName
+	}
+	return nil
+}
+
+// NativeName is a common/official name pair in a given language.
+type NativeName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Official string `protobuf:"bytes,1,opt,name=official,proto3" json:"official,omitempty"`
+	Common   string `protobuf:"bytes,2,opt,name=common,proto3" json:"common,omitempty"`
+}
+
+func (x *NativeName) Reset() {
+	*x = NativeName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NativeName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NativeName) ProtoMessage() {}
+
+func (x *NativeName) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NativeName.ProtoReflect.Descriptor instead.
+func (*NativeName) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NativeName) GetOfficial() string {
+	if x != nil {
+		return x.Official
+	}
+	return ""
+}
+
+func (x *NativeName) GetCommon() string {
+	if x != nil {
+		return x.Common
+	}
+	return ""
+}
+
+// Currency describes a currency used by a country.
+type Currency struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol string `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *Currency) Reset() {
+	*x = Currency{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Currency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Currency) ProtoMessage() {}
+
+func (x *Currency) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Currency.ProtoReflect.Descriptor instead.
+func (*Currency) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Currency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Currency) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Idd holds the international direct dialing root and suffixes.
+type Idd struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Root     string   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Suffixes []string `protobuf:"bytes,2,rep,name=suffixes,proto3" json:"suffixes,omitempty"`
+}
+
+func (x *Idd) Reset() {
+	*x = Idd{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Idd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Idd) ProtoMessage() {}
+
+func (x *Idd) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Idd.ProtoReflect.Descriptor instead.
+func (*Idd) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Idd) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *Idd) GetSuffixes() []string {
+	if x != nil {
+		return x.Suffixes
+	}
+	return nil
+}
+
+// Demonym holds the female and male demonyms in a given language.
+type Demonym struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	F string `protobuf:"bytes,1,opt,name=f,proto3" json:"f,omitempty"`
+	M string `protobuf:"bytes,2,opt,name=m,proto3" json:"m,omitempty"`
+}
+
+func (x *Demonym) Reset() {
+	*x = Demonym{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Demonym) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Demonym) ProtoMessage() {}
+
+func (x *Demonym) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Demonym.ProtoReflect.Descriptor instead.
+func (*Demonym) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Demonym) GetF() string {
+	if x != nil {
+		return x.F
+	}
+	return ""
+}
+
+func (x *Demonym) GetM() string {
+	if x != nil {
+		return x.M
+	}
+	return ""
+}
+
+// Maps holds links to the country on external map services.
+type Maps struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GoogleMaps     string `protobuf:"bytes,1,opt,name=google_maps,json=googleMaps,proto3" json:"google_maps,omitempty"`
+	OpenStreetMaps string `protobuf:"bytes,2,opt,name=open_street_maps,json=openStreetMaps,proto3" json:"open_street_maps,omitempty"`
+}
+
+func (x *Maps) Reset() {
+	*x = Maps{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Maps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maps) ProtoMessage() {}
+
+func (x *Maps) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maps.ProtoReflect.Descriptor instead.
+func (*Maps) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Maps) GetGoogleMaps() string {
+	if x != nil {
+		return x.GoogleMaps
+	}
+	return ""
+}
+
+func (x *Maps) GetOpenStreetMaps() string {
+	if x != nil {
+		return x.OpenStreetMaps
+	}
+	return ""
+}
+
+// Car holds the driving side and international vehicle registration codes.
+type Car struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signs []string `protobuf:"bytes,1,rep,name=signs,proto3" json:"signs,omitempty"`
+	Side  string   `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+}
+
+func (x *Car) Reset() {
+	*x = Car{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Car) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Car) ProtoMessage() {}
+
+func (x *Car) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Car.ProtoReflect.Descriptor instead.
+func (*Car) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Car) GetSigns() []string {
+	if x != nil {
+		return x.Signs
+	}
+	return nil
+}
+
+func (x *Car) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+// Images holds PNG/SVG image links such as flags or coats of arms.
+type Images struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Png string `protobuf:"bytes,1,opt,name=png,proto3" json:"png,omitempty"`
+	Svg string `protobuf:"bytes,2,opt,name=svg,proto3" json:"svg,omitempty"`
+	Alt string `protobuf:"bytes,3,opt,name=alt,proto3" json:"alt,omitempty"`
+}
+
+func (x *Images) Reset() {
+	*x = Images{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Images) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Images) ProtoMessage() {}
+
+func (x *Images) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Images.ProtoReflect.Descriptor instead.
+func (*Images) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Images) GetPng() string {
+	if x != nil {
+		return x.Png
+	}
+	return ""
+}
+
+func (x *Images) GetSvg() string {
+	if x != nil {
+		return x.Svg
+	}
+	return ""
+}
+
+func (x *Images) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+// CapitalInfo holds the coordinates of the capital city.
+type CapitalInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latlng []float64 `protobuf:"fixed64,1,rep,packed,name=latlng,proto3" json:"latlng,omitempty"`
+}
+
+func (x *CapitalInfo) Reset() {
+	*x = CapitalInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CapitalInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapitalInfo) ProtoMessage() {}
+
+func (x *CapitalInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapitalInfo.ProtoReflect.Descriptor instead.
+func (*CapitalInfo) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CapitalInfo) GetLatlng() []float64 {
+	if x != nil {
+		return x.Latlng
+	}
+	return nil
+}
+
+// PostalCode holds the postal code format and validation regex.
+type PostalCode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Regex  string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+}
+
+func (x *PostalCode) Reset() {
+	*x = PostalCode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostalCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostalCode) ProtoMessage() {}
+
+func (x *PostalCode) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostalCode.ProtoReflect.Descriptor instead.
+func (*PostalCode) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PostalCode) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *PostalCode) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+// CountryMatch is a country found by name, with its match score between 0 and 1.
+type CountryMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country *Country `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Score   float64  `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *CountryMatch) Reset() {
+	*x = CountryMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryMatch) ProtoMessage() {}
+
+func (x *CountryMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryMatch.ProtoReflect.Descriptor instead.
+func (*CountryMatch) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CountryMatch) GetCountry() *Country {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *CountryMatch) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// CountryListResponse is the body of GET /countries.
+type CountryListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country []*Country `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+}
+
+func (x *CountryListResponse) Reset() {
+	*x = CountryListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryListResponse) ProtoMessage() {}
+
+func (x *CountryListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryListResponse.ProtoReflect.Descriptor instead.
+func (*CountryListResponse) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CountryListResponse) GetCountry() []*Country {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+// CountryDetailsResponse is the body of GET /country/{code} and GET /country?codes=.
+type CountryDetailsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CountryData []*Country `protobuf:"bytes,1,rep,name=country_data,json=countryData,proto3" json:"country_data,omitempty"`
+}
+
+func (x *CountryDetailsResponse) Reset() {
+	*x = CountryDetailsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryDetailsResponse) ProtoMessage() {}
+
+func (x *CountryDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryDetailsResponse.ProtoReflect.Descriptor instead.
+func (*CountryDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CountryDetailsResponse) GetCountryData() []*Country {
+	if x != nil {
+		return x.CountryData
+	}
+	return nil
+}
+
+// CountryMatchesResponse is the body of GET /country?name=, ranked best match first.
+type CountryMatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CountryData []*CountryMatch `protobuf:"bytes,1,rep,name=country_data,json=countryData,proto3" json:"country_data,omitempty"`
+}
+
+func (x *CountryMatchesResponse) Reset() {
+	*x = CountryMatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryMatchesResponse) ProtoMessage() {}
+
+func (x *CountryMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryMatchesResponse.ProtoReflect.Descriptor instead.
+func (*CountryMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CountryMatchesResponse) GetCountryData() []*CountryMatch {
+	if x != nil {
+		return x.CountryData
+	}
+	return nil
+}
+
+// CountryFilterResponse is the body of GET /countries/filter.
+type CountryFilterResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Common names of the countries on the page, when no fields were requested.
+	Country []string `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+	Total   int32    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Zero when paging by cursor.
+	Page       int32  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalPages int32  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	Next       string `protobuf:"bytes,6,opt,name=next,proto3" json:"next,omitempty"`
+	Prev       string `protobuf:"bytes,7,opt,name=prev,proto3" json:"prev,omitempty"`
+	NextCursor string `protobuf:"bytes,8,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// The requested fields of each country on the page, when fields were requested.
+	Countries []*Country `protobuf:"bytes,9,rep,name=countries,proto3" json:"countries,omitempty"`
+}
+
+func (x *CountryFilterResponse) Reset() {
+	*x = CountryFilterResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountryFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryFilterResponse) ProtoMessage() {}
+
+func (x *CountryFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_country_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryFilterResponse.ProtoReflect.Descriptor instead.
+func (*CountryFilterResponse) Descriptor() ([]byte, []int) {
+	return file_country_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CountryFilterResponse) GetCountry() []string {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *CountryFilterResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CountryFilterResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *CountryFilterResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CountryFilterResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *CountryFilterResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+func (x *CountryFilterResponse) GetPrev() string {
+	if x != nil {
+		return x.Prev
+	}
+	return ""
+}
+
+func (x *CountryFilterResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *CountryFilterResponse) GetCountries() []*Country {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+var File_country_proto protoreflect.FileDescriptor
+
+var file_country_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x22, 0x85, 0x0d, 0x0a, 0x07,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x63, 0x61, 0x32, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x63, 0x61, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x63,
+	0x6e, 0x33, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x63, 0x6e, 0x33, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x63, 0x61, 0x33, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x63,
+	0x61, 0x33, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x6f, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x69, 0x6f, 0x63, 0x12, 0x25, 0x0a, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0b, 0x69,
+	0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x43, 0x0a, 0x0a, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x03, 0x69, 0x64, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x64, 0x64, 0x52, 0x03, 0x69, 0x64, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61,
+	0x70, 0x69, 0x74, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x61, 0x70,
+	0x69, 0x74, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x74,
+	0x53, 0x70, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12,
+	0x40, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x49, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x61, 0x74, 0x6c, 0x6e, 0x67, 0x18, 0x12, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x61,
+	0x74, 0x6c, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x61, 0x6e, 0x64, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x61, 0x6e, 0x64, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x62, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x72, 0x65, 0x61, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72,
+	0x65, 0x61, 0x12, 0x3d, 0x0a, 0x08, 0x64, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x6d, 0x73, 0x18, 0x16,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x44, 0x65, 0x6d, 0x6f, 0x6e, 0x79,
+	0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x64, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x6d,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x66, 0x6c, 0x61, 0x67, 0x12, 0x24, 0x0a, 0x04, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x61, 0x70, 0x73, 0x52, 0x04, 0x6d, 0x61, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x04, 0x67,
+	0x69, 0x6e, 0x69, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x47,
+	0x69, 0x6e, 0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x67, 0x69, 0x6e, 0x69, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x69, 0x66, 0x61, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69,
+	0x66, 0x61, 0x12, 0x21, 0x0a, 0x03, 0x63, 0x61, 0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x72,
+	0x52, 0x03, 0x63, 0x61, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
+	0x65, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f,
+	0x6e, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x1f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x34, 0x0a,
+	0x0c, 0x63, 0x6f, 0x61, 0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x61, 0x72, 0x6d, 0x73, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x0a, 0x63, 0x6f, 0x61, 0x74, 0x4f, 0x66, 0x41,
+	0x72, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6f, 0x66, 0x5f,
+	0x77, 0x65, 0x65, 0x6b, 0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x4f, 0x66, 0x57, 0x65, 0x65, 0x6b, 0x12, 0x3a, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x69, 0x74,
+	0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x69, 0x74,
+	0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65,
+	0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x53, 0x0a, 0x0f,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x57, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50, 0x0a, 0x0d, 0x44, 0x65, 0x6d, 0x6f,
+	0x6e, 0x79, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x6d, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x37, 0x0a, 0x09, 0x47, 0x69,
+	0x6e, 0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64,
+	0x65, 0x6e, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6f,
+	0x66, 0x66, 0x69, 0x63, 0x69, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f,
+	0x66, 0x66, 0x69, 0x63, 0x69, 0x61, 0x6c, 0x12, 0x48, 0x0a, 0x0b, 0x6e, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x1a, 0x55, 0x0a, 0x0f, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x0a, 0x4e, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x66, 0x66, 0x69, 0x63, 0x69,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x66, 0x66, 0x69, 0x63, 0x69,
+	0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x08, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x22, 0x35, 0x0a, 0x03, 0x49, 0x64, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x25, 0x0a, 0x07, 0x44, 0x65, 0x6d,
+	0x6f, 0x6e, 0x79, 0x6d, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x01, 0x66, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x6d,
+	0x22, 0x51, 0x0a, 0x04, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x70, 0x65,
+	0x6e, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x70, 0x65, 0x6e, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d,
+	0x61, 0x70, 0x73, 0x22, 0x2f, 0x0a, 0x03, 0x43, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69,
+	0x67, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6e, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x69, 0x64, 0x65, 0x22, 0x3e, 0x0a, 0x06, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6e, 0x67,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x76, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
+	0x76, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x61, 0x6c, 0x74, 0x22, 0x25, 0x0a, 0x0b, 0x43, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x74, 0x6c, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x61, 0x74, 0x6c, 0x6e, 0x67, 0x22, 0x3a, 0x0a, 0x0a, 0x50,
+	0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x22, 0x53, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x44, 0x0a, 0x13,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x22, 0x50, 0x0a, 0x16, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x0c,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0x55, 0x0a, 0x16, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0b,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x44, 0x61, 0x74, 0x61, 0x22, 0x95, 0x02, 0x0a, 0x15,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x72, 0x65, 0x76, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x72, 0x65, 0x76, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x42, 0x22, 0x5a, 0x20, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x61,
+	0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_country_proto_rawDescOnce sync.Once
+	file_country_proto_rawDescData = file_country_proto_rawDesc
+)
+
+func file_country_proto_rawDescGZIP() []byte {
+	file_country_proto_rawDescOnce.Do(func() {
+		file_country_proto_rawDescData = protoimpl.X.CompressGZIP(file_country_proto_rawDescData)
+	})
+	return file_country_proto_rawDescData
+}
+
+var file_country_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_country_proto_goTypes = []any{
+	(*Country)(nil),                // 0: country.v1.Country
+	(*CountryName)(nil),            // 1: country.v1.CountryName
+	(*NativeName)(nil),             // 2: country.v1.NativeName
+	(*Currency)(nil),               // 3: country.v1.Currency
+	(*Idd)(nil),                    // 4: country.v1.Idd
+	(*Demonym)(nil),                // 5: country.v1.Demonym
+	(*Maps)(nil),                   // 6: country.v1.Maps
+	(*Car)(nil),                    // 7: country.v1.Car
+	(*Images)(nil),                 // 8: country.v1.Images
+	(*CapitalInfo)(nil),            // 9: country.v1.CapitalInfo
+	(*PostalCode)(nil),             // 10: country.v1.PostalCode
+	(*CountryMatch)(nil),           // 11: country.v1.CountryMatch
+	(*CountryListResponse)(nil),    // 12: country.v1.CountryListResponse
+	(*CountryDetailsResponse)(nil), // 13: country.v1.CountryDetailsResponse
+	(*CountryMatchesResponse)(nil), // 14: country.v1.CountryMatchesResponse
+	(*CountryFilterResponse)(nil),  // 15: country.v1.CountryFilterResponse
+	nil,                            // 16: country.v1.Country.CurrenciesEntry
+	nil,                            // 17: country.v1.Country.LanguagesEntry
+	nil,                            // 18: country.v1.Country.TranslationsEntry
+	nil,                            // 19: country.v1.Country.DemonymsEntry
+	nil,                            // 20: country.v1.Country.GiniEntry
+	nil,                            // 21: country.v1.CountryName.NativeNameEntry
+}
+var file_country_proto_depIdxs = []int32{
+	1,  // 0: country.v1.Country.name:type_name -> country.v1.CountryName
+	16, // 1: country.v1.Country.currencies:type_name -> country.v1.Country.CurrenciesEntry
+	4,  // 2: country.v1.Country.idd:type_name -> country.v1.Idd
+	17, // 3: country.v1.Country.languages:type_name -> country.v1.Country.LanguagesEntry
+	18, // 4: country.v1.Country.translations:type_name -> country.v1.Country.TranslationsEntry
+	19, // 5: country.v1.Country.demonyms:type_name -> country.v1.Country.DemonymsEntry
+	6,  // 6: country.v1.Country.maps:type_name -> country.v1.Maps
+	20, // 7: country.v1.Country.gini:type_name -> country.v1.Country.GiniEntry
+	7,  // 8: country.v1.Country.car:type_name -> country.v1.Car
+	8,  // 9: country.v1.Country.flags:type_name -> country.v1.Images
+	8,  // 10: country.v1.Country.coat_of_arms:type_name -> country.v1.Images
+	9,  // 11: country.v1.Country.capital_info:type_name -> country.v1.CapitalInfo
+	10, // 12: country.v1.Country.postal_code:type_name -> country.v1.PostalCode
+	21, // 13: country.v1.CountryName.native_name:type_name -> country.v1.CountryName.NativeNameEntry
+	0,  // 14: country.v1.CountryMatch.country:type_name -> country.v1.Country
+	0,  // 15: country.v1.CountryListResponse.country:type_name -> country.v1.Country
+	0,  // 16: country.v1.CountryDetailsResponse.country_data:type_name -> country.v1.Country
+	11, // 17: country.v1.CountryMatchesResponse.country_data:type_name -> country.v1.CountryMatch
+	0,  // 18: country.v1.CountryFilterResponse.countries:type_name -> country.v1.Country
+	3,  // 19: country.v1.Country.CurrenciesEntry.value:type_name -> country.v1.Currency
+	2,  // 20: country.v1.Country.TranslationsEntry.value:type_name -> country.v1.NativeName
+	5,  // 21: country.v1.Country.DemonymsEntry.value:type_name -> country.v1.Demonym
+	2,  // 22: country.v1.CountryName.NativeNameEntry.value:type_name -> country.v1.NativeName
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_country_proto_init() }
+func file_country_proto_init() {
+	if File_country_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_country_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Country); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*NativeName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Currency); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Idd); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Demonym); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Maps); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Car); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Images); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*CapitalInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*PostalCode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryDetailsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*CountryFilterResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_country_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_country_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_country_proto_goTypes,
+		DependencyIndexes: file_country_proto_depIdxs,
+		MessageInfos:      file_country_proto_msgTypes,
+	}.Build()
+	File_country_proto = out.File
+	file_country_proto_rawDesc = nil
+	file_country_proto_goTypes = nil
+	file_country_proto_depIdxs = nil
+}
@@ -0,0 +1,746 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
+// source: country_service.proto
+
+package countrypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuthenticateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *AuthenticateRequest) Reset() {
+	*x = AuthenticateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateRequest) ProtoMessage() {}
+
+func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_country_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateRequest.ProtoReflect.Descriptor instead.
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
+	return file_country_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthenticateRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuthenticateRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AuthenticateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *AuthenticateResponse) Reset() {
+	*x = AuthenticateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateResponse) ProtoMessage() {}
+
+func (x *AuthenticateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_country_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateResponse.ProtoReflect.Descriptor instead.
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
+	return file_country_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthenticateResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetCountryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Lookup:
+	//	*GetCountryRequest_Code
+	//	*GetCountryRequest_Name
+	Lookup isGetCountryRequest_Lookup `protobuf_oneof:"lookup"`
+	// Name match mode: exact, official, prefix, substring (default) or fuzzy.
+	Match string `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	// Comma-separated dotted field paths to return, e.g. "name.common,capital".
+	Fields string `protobuf:"bytes,4,opt,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *GetCountryRequest) Reset() {
+	*x = GetCountryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCountryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountryRequest) ProtoMessage() {}
+
+func (x *GetCountryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_country_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountryRequest.ProtoReflect.Descriptor instead.
+func (*GetCountryRequest) Descriptor() ([]byte, []int) {
+	return file_country_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *GetCountryRequest) GetLookup() isGetCountryRequest_Lookup {
+	if m != nil {
+		return m.Lookup
+	}
+	return nil
+}
+
+func (x *GetCountryRequest) GetCode() string {
+	if x, ok := x.GetLookup().(*GetCountryRequest_Code); ok {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetCountryRequest) GetName() string {
+	if x, ok := x.GetLookup().(*GetCountryRequest_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetCountryRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *GetCountryRequest) GetFields() string {
+	if x != nil {
+		return x.Fields
+	}
+	return ""
+}
+
+type isGetCountryRequest_Lookup interface {
+	isGetCountryRequest_Lookup()
+}
+
+type GetCountryRequest_Code struct {
+	// A cca2, cca3, ccn3 or cioc code.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3,oneof"`
+}
+
+type GetCountryRequest_Name struct {
+	// A country name, matched with the match mode.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*GetCountryRequest_Code) isGetCountryRequest_Lookup() {}
+
+func (*GetCountryRequest_Name) isGetCountryRequest_Lookup() {}
+
+type ListCountriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Comma-separated sort keys, prefixed with - for descending order, e.g. "region,-population".
+	Sort string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Comma-separated dotted field paths to return, e.g. "name.common,capital".
+	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *ListCountriesRequest) Reset() {
+	*x = ListCountriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCountriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCountriesRequest) ProtoMessage() {}
+
+func (x *ListCountriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_country_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCountriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCountriesRequest) Descriptor() ([]byte, []int) {
+	return file_country_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCountriesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListCountriesRequest) GetFields() string {
+	if x != nil {
+		return x.Fields
+	}
+	return ""
+}
+
+// FilterCountriesRequest carries the query parameters of GET /api/v1/countries/filter.
+type FilterCountriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PopulationMin *float64 `protobuf:"fixed64,1,opt,name=population_min,json=populationMin,proto3,oneof" json:"population_min,omitempty"`
+	PopulationMax *float64 `protobuf:"fixed64,2,opt,name=population_max,json=populationMax,proto3,oneof" json:"population_max,omitempty"`
+	AreaMin       *float64 `protobuf:"fixed64,3,opt,name=area_min,json=areaMin,proto3,oneof" json:"area_min,omitempty"`
+	AreaMax       *float64 `protobuf:"fixed64,4,opt,name=area_max,json=areaMax,proto3,oneof" json:"area_max,omitempty"`
+	DensityMin    *float64 `protobuf:"fixed64,5,opt,name=density_min,json=densityMin,proto3,oneof" json:"density_min,omitempty"`
+	DensityMax    *float64 `protobuf:"fixed64,6,opt,name=density_max,json=densityMax,proto3,oneof" json:"density_max,omitempty"`
+	Language      []string `protobuf:"bytes,7,rep,name=language,proto3" json:"language,omitempty"`
+	// any (default) or all.
+	LanguageMatch           string   `protobuf:"bytes,8,opt,name=language_match,json=languageMatch,proto3" json:"language_match,omitempty"`
+	IncludeUnknownLanguages bool     `protobuf:"varint,9,opt,name=include_unknown_languages,json=includeUnknownLanguages,proto3" json:"include_unknown_languages,omitempty"`
+	Region                  []string `protobuf:"bytes,10,rep,name=region,proto3" json:"region,omitempty"`
+	Subregion               []string `protobuf:"bytes,11,rep,name=subregion,proto3" json:"subregion,omitempty"`
+	Continent               []string `protobuf:"bytes,12,rep,name=continent,proto3" json:"continent,omitempty"`
+	Currency                []string `protobuf:"bytes,13,rep,name=currency,proto3" json:"currency,omitempty"`
+	Timezone                []string `protobuf:"bytes,14,rep,name=timezone,proto3" json:"timezone,omitempty"`
+	CallingCode             []string `protobuf:"bytes,15,rep,name=calling_code,json=callingCode,proto3" json:"calling_code,omitempty"`
+	Landlocked              *bool    `protobuf:"varint,16,opt,name=landlocked,proto3,oneof" json:"landlocked,omitempty"`
+	Independent             *bool    `protobuf:"varint,17,opt,name=independent,proto3,oneof" json:"independent,omitempty"`
+	UnMember                *bool    `protobuf:"varint,18,opt,name=un_member,json=unMember,proto3,oneof" json:"un_member,omitempty"`
+	Sort                    string   `protobuf:"bytes,19,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page                    int32    `protobuf:"varint,20,opt,name=page,proto3" json:"page,omitempty"`
+	Limit                   int32    `protobuf:"varint,21,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor from a previous response; cannot be combined with page.
+	Cursor string `protobuf:"bytes,22,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Fields string `protobuf:"bytes,23,opt,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *FilterCountriesRequest) Reset() {
+	*x = FilterCountriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_country_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterCountriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterCountriesRequest) ProtoMessage() {}
+
+func (x *FilterCountriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_country_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterCountriesRequest.ProtoReflect.Descriptor instead.
+func (*FilterCountriesRequest) Descriptor() ([]byte, []int) {
+	return file_country_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FilterCountriesRequest) GetPopulationMin() float64 {
+	if x != nil && x.PopulationMin != nil {
+		return *x.PopulationMin
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetPopulationMax() float64 {
+	if x != nil && x.PopulationMax != nil {
+		return *x.PopulationMax
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetAreaMin() float64 {
+	if x != nil && x.AreaMin != nil {
+		return *x.AreaMin
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetAreaMax() float64 {
+	if x != nil && x.AreaMax != nil {
+		return *x.AreaMax
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetDensityMin() float64 {
+	if x != nil && x.DensityMin != nil {
+		return *x.DensityMin
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetDensityMax() float64 {
+	if x != nil && x.DensityMax != nil {
+		return *x.DensityMax
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetLanguage() []string {
+	if x != nil {
+		return x.Language
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetLanguageMatch() string {
+	if x != nil {
+		return x.LanguageMatch
+	}
+	return ""
+}
+
+func (x *FilterCountriesRequest) GetIncludeUnknownLanguages() bool {
+	if x != nil {
+		return x.IncludeUnknownLanguages
+	}
+	return false
+}
+
+func (x *FilterCountriesRequest) GetRegion() []string {
+	if x != nil {
+		return x.Region
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetSubregion() []string {
+	if x != nil {
+		return x.Subregion
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetContinent() []string {
+	if x != nil {
+		return x.Continent
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetCurrency() []string {
+	if x != nil {
+		return x.Currency
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetTimezone() []string {
+	if x != nil {
+		return x.Timezone
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetCallingCode() []string {
+	if x != nil {
+		return x.CallingCode
+	}
+	return nil
+}
+
+func (x *FilterCountriesRequest) GetLandlocked() bool {
+	if x != nil && x.Landlocked != nil {
+		return *x.Landlocked
+	}
+	return false
+}
+
+func (x *FilterCountriesRequest) GetIndependent() bool {
+	if x != nil && x.Independent != nil {
+		return *x.Independent
+	}
+	return false
+}
+
+func (x *FilterCountriesRequest) GetUnMember() bool {
+	if x != nil && x.UnMember != nil {
+		return *x.UnMember
+	}
+	return false
+}
+
+func (x *FilterCountriesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *FilterCountriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FilterCountriesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FilterCountriesRequest) GetFields() string {
+	if x != nil {
+		return x.Fields
+	}
+	return ""
+}
+
+var File_country_service_proto protoreflect.FileDescriptor
+
+var file_country_service_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x1a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x4d, 0x0a, 0x13, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x22, 0x2c, 0x0a, 0x14, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x77, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x42, 0x08,
+	0x0a, 0x06, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x22, 0x42, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x93, 0x07, 0x0a,
+	0x16, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0e, 0x70, 0x6f, 0x70, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48,
+	0x00, 0x52, 0x0d, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e,
+	0x88, 0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0d, 0x70,
+	0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12,
+	0x1e, 0x0a, 0x08, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x02, 0x52, 0x07, 0x61, 0x72, 0x65, 0x61, 0x4d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12,
+	0x1e, 0x0a, 0x08, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x03, 0x52, 0x07, 0x61, 0x72, 0x65, 0x61, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12,
+	0x24, 0x0a, 0x0b, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x04, 0x52, 0x0a, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x4d,
+	0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x05, 0x52, 0x0a, 0x64, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x79, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3a,
+	0x0a, 0x19, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x17, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x6e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61,
+	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0a, 0x6c, 0x61, 0x6e,
+	0x64, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x48, 0x06, 0x52,
+	0x0a, 0x6c, 0x61, 0x6e, 0x64, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25,
+	0x0a, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x07, 0x52, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
+	0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x75, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x48, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x70, 0x6f, 0x70,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x61, 0x72, 0x65, 0x61, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x61, 0x72, 0x65,
+	0x61, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x79, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x79, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x6c, 0x61, 0x6e, 0x64, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x65, 0x6e, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x75, 0x6e, 0x5f, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x32, 0xc9, 0x02, 0x0a, 0x0e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x48, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x22,
+	0x5a, 0x20, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_country_service_proto_rawDescOnce sync.Once
+	file_country_service_proto_rawDescData = file_country_service_proto_rawDesc
+)
+
+func file_country_service_proto_rawDescGZIP() []byte {
+	file_country_service_proto_rawDescOnce.Do(func() {
+		file_country_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_country_service_proto_rawDescData)
+	})
+	return file_country_service_proto_rawDescData
+}
+
+var file_country_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_country_service_proto_goTypes = []any{
+	(*AuthenticateRequest)(nil),    // 0: country.v1.AuthenticateRequest
+	(*AuthenticateResponse)(nil),   // 1: country.v1.AuthenticateResponse
+	(*GetCountryRequest)(nil),      // 2: country.v1.GetCountryRequest
+	(*ListCountriesRequest)(nil),   // 3: country.v1.ListCountriesRequest
+	(*FilterCountriesRequest)(nil), // 4: country.v1.FilterCountriesRequest
+	(*Country)(nil),                // 5: country.v1.Country
+	(*CountryFilterResponse)(nil),  // 6: country.v1.CountryFilterResponse
+}
+var file_country_service_proto_depIdxs = []int32{
+	0, // 0: country.v1.CountryService.Authenticate:input_type -> country.v1.AuthenticateRequest
+	2, // 1: country.v1.CountryService.GetCountry:input_type -> country.v1.GetCountryRequest
+	3, // 2: country.v1.CountryService.ListCountries:input_type -> country.v1.ListCountriesRequest
+	4, // 3: country.v1.CountryService.FilterCountries:input_type -> country.v1.FilterCountriesRequest
+	1, // 4: country.v1.CountryService.Authenticate:output_type -> country.v1.AuthenticateResponse
+	5, // 5: country.v1.CountryService.GetCountry:output_type -> country.v1.Country
+	5, // 6: country.v1.CountryService.ListCountries:output_type -> country.v1.Country
+	6, // 7: country.v1.CountryService.FilterCountries:output_type -> country.v1.CountryFilterResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_country_service_proto_init() }
+func file_country_service_proto_init() {
+	if File_country_service_proto != nil {
+		return
+	}
+	file_country_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_country_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*AuthenticateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*AuthenticateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetCountryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListCountriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_country_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*FilterCountriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_country_service_proto_msgTypes[2].OneofWrappers = []any{
+		(*GetCountryRequest_Code)(nil),
+		(*GetCountryRequest_Name)(nil),
+	}
+	file_country_service_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_country_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_country_service_proto_goTypes,
+		DependencyIndexes: file_country_service_proto_depIdxs,
+		MessageInfos:      file_country_service_proto_msgTypes,
+	}.Build()
+	File_country_service_proto = out.File
+	file_country_service_proto_rawDesc = nil
+	file_country_service_proto_goTypes = nil
+	file_country_service_proto_depIdxs = nil
+}
@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v4.25.3
+// source: country_service.proto
+
+package countrypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CountryService_Authenticate_FullMethodName    = "/country.v1.CountryService/Authenticate"
+	CountryService_GetCountry_FullMethodName      = "/country.v1.CountryService/GetCountry"
+	CountryService_ListCountries_FullMethodName   = "/country.v1.CountryService/ListCountries"
+	CountryService_FilterCountries_FullMethodName = "/country.v1.CountryService/FilterCountries"
+)
+
+// CountryServiceClient is the client API for CountryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CountryService serves the country catalog over gRPC. Every call except Authenticate needs
+// an "authorization: Bearer <token>" metadata entry holding a token from Authenticate.
+type CountryServiceClient interface {
+	// Authenticate exchanges credentials for a JWT, like POST /api/v1/auth.
+	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// GetCountry looks up one country by code, or the best match for a name.
+	GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error)
+	// ListCountries streams every country, like GET /api/v1/countries/export.
+	ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Country], error)
+	// FilterCountries returns one page of filtered countries, like GET /api/v1/countries/filter.
+	FilterCountries(ctx context.Context, in *FilterCountriesRequest, opts ...grpc.CallOption) (*CountryFilterResponse, error)
+}
+
+type countryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCountryServiceClient(cc grpc.ClientConnInterface) CountryServiceClient {
+	return &countryServiceClient{cc}
+}
+
+func (c *countryServiceClient) Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthenticateResponse)
+	err := c.cc.Invoke(ctx, CountryService_Authenticate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Country)
+	err := c.cc.Invoke(ctx, CountryService_GetCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *countryServiceClient) ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Country], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CountryService_ServiceDesc.Streams[0], CountryService_ListCountries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListCountriesRequest, Country]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryService_ListCountriesClient = grpc.ServerStreamingClient[Country]
+
+func (c *countryServiceClient) FilterCountries(ctx context.Context, in *FilterCountriesRequest, opts ...grpc.CallOption) (*CountryFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountryFilterResponse)
+	err := c.cc.Invoke(ctx, CountryService_FilterCountries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CountryServiceServer is the server API for CountryService service.
+// All implementations must embed UnimplementedCountryServiceServer
+// for forward compatibility.
+//
+// CountryService serves the country catalog over gRPC. Every call except Authenticate needs
+// an "authorization: Bearer <token>" metadata entry holding a token from Authenticate.
+type CountryServiceServer interface {
+	// Authenticate exchanges credentials for a JWT, like POST /api/v1/auth.
+	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// GetCountry looks up one country by code, or the best match for a name.
+	GetCountry(context.Context, *GetCountryRequest) (*Country, error)
+	// ListCountries streams every country, like GET /api/v1/countries/export.
+	ListCountries(*ListCountriesRequest, grpc.ServerStreamingServer[Country]) error
+	// FilterCountries returns one page of filtered countries, like GET /api/v1/countries/filter.
+	FilterCountries(context.Context, *FilterCountriesRequest) (*CountryFilterResponse, error)
+	mustEmbedUnimplementedCountryServiceServer()
+}
+
+// UnimplementedCountryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCountryServiceServer struct{}
+
+func (UnimplementedCountryServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
+}
+func (UnimplementedCountryServiceServer) GetCountry(context.Context, *GetCountryRequest) (*Country, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountry not implemented")
+}
+func (UnimplementedCountryServiceServer) ListCountries(*ListCountriesRequest, grpc.ServerStreamingServer[Country]) error {
+	return status.Errorf(codes.Unimplemented, "method ListCountries not implemented")
+}
+func (UnimplementedCountryServiceServer) FilterCountries(context.Context, *FilterCountriesRequest) (*CountryFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilterCountries not implemented")
+}
+func (UnimplementedCountryServiceServer) mustEmbedUnimplementedCountryServiceServer() {}
+func (UnimplementedCountryServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCountryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CountryServiceServer will
+// result in compilation errors.
+type UnsafeCountryServiceServer interface {
+	mustEmbedUnimplementedCountryServiceServer()
+}
+
+func RegisterCountryServiceServer(s grpc.ServiceRegistrar, srv CountryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCountryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CountryService_ServiceDesc, srv)
+}
+
+func _CountryService_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).Authenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_Authenticate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).Authenticate(ctx, req.(*AuthenticateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_GetCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).GetCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_GetCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).GetCountry(ctx, req.(*GetCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CountryService_ListCountries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListCountriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CountryServiceServer).ListCountries(m, &grpc.GenericServerStream[ListCountriesRequest, Country]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CountryService_ListCountriesServer = grpc.ServerStreamingServer[Country]
+
+func _CountryService_FilterCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CountryServiceServer).FilterCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CountryService_FilterCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CountryServiceServer).FilterCountries(ctx, req.(*FilterCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CountryService_ServiceDesc is the grpc.ServiceDesc for CountryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CountryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "country.v1.CountryService",
+	HandlerType: (*CountryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Authenticate",
+			Handler:    _CountryService_Authenticate_Handler,
+		},
+		{
+			MethodName: "GetCountry",
+			Handler:    _CountryService_GetCountry_Handler,
+		},
+		{
+			MethodName: "FilterCountries",
+			Handler:    _CountryService_FilterCountries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCountries",
+			Handler:       _CountryService_ListCountries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "country_service.proto",
+}
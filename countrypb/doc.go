@@ -0,0 +1,4 @@
+// Package countrypb holds the Protocol Buffers schema of the Country API and its generated Go types.
+package countrypb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative country.proto country_service.proto
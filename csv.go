@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// utf8BOM is written before the header row when requested, so spreadsheet tools detect UTF-8.
+const utf8BOM = "\ufeff"
+
+// countryCSVColumns are the flattened CSV columns of Country in declaration order. Nested
+// structs such as name or idd become dotted columns; maps and lists stay in a single column.
+var countryCSVColumns = csvColumnsOf(reflect.TypeOf(Country{}), "")
+
+// csvColumnsOf returns the dotted JSON paths of the leaf fields of a struct type.
+func csvColumnsOf(t reflect.Type, prefix string) []string {
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			columns = append(columns, csvColumnsOf(fieldType, prefix+name+".")...)
+			continue
+		}
+		columns = append(columns, prefix+name)
+	}
+	return columns
+}
+
+// csvColumns returns the country columns selected by the projection, or all of them with a nil
+// projection. The result can be appended to without touching the shared column list.
+func (p fieldProjection) csvColumns() []string {
+	if p == nil {
+		return slices.Clip(countryCSVColumns)
+	}
+
+	var columns []string
+	for _, column := range countryCSVColumns {
+		segments := strings.Split(column, ".")
+		for _, path := range p {
+			if pathOverlaps(path, segments) {
+				columns = append(columns, column)
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// pathOverlaps reports whether a projection path selects part of a column, or the column
+// is part of what the path selects.
+func pathOverlaps(path, column []string) bool {
+	for i := 0; i < len(path) && i < len(column); i++ {
+		if path[i] != "*" && path[i] != column[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bomFromRequest reads the bom query parameter of a CSV response, writing a 400 error response
+// if it is invalid. Other formats ignore it.
+func bomFromRequest(w http.ResponseWriter, r *http.Request, format string) (bool, bool) {
+	s := r.URL.Query().Get("bom")
+	if format != formatCSV || s == "" {
+		return false, true
+	}
+
+	withBOM, err := strconv.ParseBool(s)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid bom value: must be true or false")
+		return false, false
+	}
+	return withBOM, true
+}
+
+// writeCSVResponse writes items as CSV rows with a header row of columns. Items are countries,
+// matches or projected countries; each column is looked up by its dotted path in their JSON form.
+// withBOM prefixes the body with a UTF-8 byte order mark.
+func writeCSVResponse(w http.ResponseWriter, r *http.Request, columns []string, items []interface{}, withBOM bool) {
+	records := make([][]string, 0, len(items)+1)
+	records = append(records, columns)
+	for _, item := range items {
+		record, err := csvRecord(item, columns)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Error encoding CSV response")
+			return
+		}
+		records = append(records, record)
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8; header=present")
+	w.Header().Set("Content-Disposition", `attachment; filename="countries.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	if withBOM {
+		w.Write([]byte(utf8BOM))
+	}
+	csv.NewWriter(w).WriteAll(records)
+}
+
+// csvRecord returns the cells of item for each column.
+func csvRecord(item interface{}, columns []string) ([]string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		value := generic
+		for _, segment := range strings.Split(column, ".") {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = object[segment]
+		}
+		record[i] = csvCell(value, false)
+	}
+	return record, nil
+}
+
+// csvCell flattens a decoded JSON value into one cell. List elements are joined with "; "
+// and map entries are written as key=value sorted by key, so the output is deterministic.
+// Nested lists and maps are wrapped in parentheses.
+func csvCell(value interface{}, nested bool) string {
+	var parts []string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		for _, element := range v {
+			parts = append(parts, csvCell(element, true))
+		}
+	case map[string]interface{}:
+		keys := mapKeys(v)
+		sort.Strings(keys)
+		for _, key := range keys {
+			parts = append(parts, key+"="+csvCell(v[key], true))
+		}
+	default:
+		return fmt.Sprint(v)
+	}
+
+	joined := strings.Join(parts, "; ")
+	if nested && len(parts) > 0 {
+		return "(" + joined + ")"
+	}
+	return joined
+}
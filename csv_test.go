@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestCountryDetailsCSVScore checks that name lookups in CSV fill the score column, with and
+// without a field projection.
+func TestCountryDetailsCSVScore(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	for _, query := range []string{"name=india&format=csv", "name=india&format=csv&fields=name.common"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/country?"+query, nil)
+		rec := httptest.NewRecorder()
+		CountryDetailsHandler(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", query, rec.Code, rec.Body)
+		}
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: reading CSV: %v", query, err)
+		}
+		if len(records) < 2 {
+			t.Fatalf("%s: no rows in %v", query, records)
+		}
+
+		header := records[0]
+		if header[len(header)-1] != "score" {
+			t.Fatalf("%s: last column is %q, want score", query, header[len(header)-1])
+		}
+		for _, record := range records[1:] {
+			score, err := strconv.ParseFloat(record[len(record)-1], 64)
+			if err != nil || score <= 0 || score > 1 {
+				t.Errorf("%s: invalid score %q in %v", query, record[len(record)-1], record)
+			}
+		}
+	}
+}
+
+// TestCSVColumnsAppend checks that appending to the full column list leaves the shared list alone,
+// as concurrent name lookups in CSV do.
+func TestCSVColumnsAppend(t *testing.T) {
+	first := append(fieldProjection(nil).csvColumns(), "score")
+	second := append(fieldProjection(nil).csvColumns(), "other")
+	if first[len(first)-1] != "score" || second[len(second)-1] != "other" {
+		t.Errorf("appended columns share storage: %q and %q", first[len(first)-1], second[len(second)-1])
+	}
+	if len(countryCSVColumns) == 0 || countryCSVColumns[len(countryCSVColumns)-1] == "score" {
+		t.Errorf("the shared column list was changed: %v", countryCSVColumns)
+	}
+}
+
+// TestCSVInvalidBOM checks that an invalid bom is rejected before any work is done or header set,
+// and that formats other than CSV ignore it.
+func TestCSVInvalidBOM(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	tests := []struct {
+		target  string
+		handler http.HandlerFunc
+		status  int
+	}{
+		{"/api/v1/countries?format=csv&bom=maybe", CountriesListHandler, http.StatusBadRequest},
+		{"/api/v1/countries/filter?format=csv&page_size=1&bom=maybe", CountriesFilterListHandler, http.StatusBadRequest},
+		{"/api/v1/country?name=india&format=csv&bom=maybe", CountryDetailsHandler, http.StatusBadRequest},
+		{"/api/v1/country?codes=NOR&format=csv&bom=maybe", CountryDetailsHandler, http.StatusBadRequest},
+		{"/api/v1/country/NOR?format=csv&bom=maybe", CountryByCodeHandler, http.StatusBadRequest},
+		{"/api/v1/countries/filter?page_size=1&bom=maybe", CountriesFilterListHandler, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+		rec := httptest.NewRecorder()
+		tt.handler(rec, mux.SetURLVars(req, map[string]string{"code": "NOR"}))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.target, rec.Code, tt.status, rec.Body)
+		}
+		if tt.status != http.StatusOK && (rec.Header().Get("Link") != "" || rec.Header().Get("X-Total-Count") != "") {
+			t.Errorf("%s: pagination headers set on an error: %v", tt.target, rec.Header())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// cursorTTL is how long a pagination cursor stays valid after it is issued.
+const cursorTTL = time.Hour
+
+var (
+	errInvalidCursor  = errors.New("Invalid cursor")
+	errExpiredCursor  = errors.New("Cursor has expired, restart from the first page")
+	errCursorMismatch = errors.New("Cursor does not match the current sort and filter parameters")
+)
+
+// cursorIgnoredParams are the query parameters that do not change the iterated result set.
+// Representation parameters are ignored so a cursor can be followed in any response format.
+var cursorIgnoredParams = []string{"cursor", "page", "limit", "fields", "format", "bom", "point"}
+
+// pageCursor marks a position in a filtered, sorted country list: the sort values and cca3
+// of the last country returned. It is bound to the sort and filter it was issued for.
+type pageCursor struct {
+	Query   string      `json:"q"`
+	Values  []sortValue `json:"v"`
+	CCA3    string      `json:"k"`
+	Expires int64       `json:"e"`
+}
+
+// newPageCursor returns a cursor positioned after last for the given request query and sort keys.
+func newPageCursor(query url.Values, keys []sortKey, last Country) pageCursor {
+	values := make([]sortValue, len(keys))
+	for i, key := range keys {
+		values[i] = sortFields[key.Field].value(last)
+	}
+
+	return pageCursor{
+		Query:   cursorQueryDigest(query),
+		Values:  values,
+		CCA3:    last.CCA3,
+		Expires: time.Now().Add(cursorTTL).Unix(),
+	}
+}
+
+// encode serializes and signs the cursor into an opaque URL-safe token.
+func (c pageCursor) encode() (string, error) {
+	key := tokenKeys.Key()
+	if key == nil {
+		return "", errNoSigningKey
+	}
+
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(key, payload)), nil
+}
+
+// decodePageCursor verifies and decodes a cursor token issued for the given request query.
+func decodePageCursor(token string, query url.Values, keys []sortKey) (pageCursor, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return pageCursor{}, errInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return pageCursor{}, errInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !validCursorSignature(payload, sig) {
+		return pageCursor{}, errInvalidCursor
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return pageCursor{}, errInvalidCursor
+	}
+	if time.Now().Unix() > c.Expires {
+		return pageCursor{}, errExpiredCursor
+	}
+	if c.Query != cursorQueryDigest(query) || len(c.Values) != len(keys) {
+		return pageCursor{}, errCursorMismatch
+	}
+
+	return c, nil
+}
+
+// after returns the index of the first country in the sorted list that comes after the cursor.
+func (c pageCursor) after(countries []Country, keys []sortKey) int {
+	for i, country := range countries {
+		if c.compare(country, keys) > 0 {
+			return i
+		}
+	}
+	return len(countries)
+}
+
+// compare orders country relative to the cursor position, using the same rules as compareCountries.
+func (c pageCursor) compare(country Country, keys []sortKey) int {
+	for i, key := range keys {
+		field := sortFields[key.Field]
+		if cmp := compareSortValues(field.value(country), c.Values[i], field.numeric, key.Desc); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return strings.Compare(country.CCA3, c.CCA3)
+}
+
+// cursorQueryDigest returns a digest of the parameters that define the result set, so a cursor
+// cannot be replayed against a different sort or filter.
+func cursorQueryDigest(query url.Values) string {
+	canonical := url.Values{}
+	for name, values := range query {
+		canonical[name] = values
+	}
+	for _, name := range cursorIgnoredParams {
+		canonical.Del(name)
+	}
+
+	sum := sha256.Sum256([]byte(canonical.Encode()))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// validCursorSignature reports whether sig signs payload under any key that tokens are still
+// accepted from, so cursors survive a key rotation like tokens do.
+func validCursorSignature(payload, sig []byte) bool {
+	for _, key := range tokenKeys.VerificationKeys() {
+		if hmac.Equal(sig, signCursor(key, payload)) {
+			return true
+		}
+	}
+	return false
+}
+
+// signCursor returns the HMAC-SHA256 of payload under a key derived from a token signing key.
+func signCursor(signing *signingKey, payload []byte) []byte {
+	key := sha256.Sum256(append([]byte("pagination-cursor:"), signing.cursorSecret...))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
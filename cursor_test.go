@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDecodePageCursorRejects checks that tampered, expired and mismatched cursors are refused.
+func TestDecodePageCursorRejects(t *testing.T) {
+	useSigningKey(t, newTestSigningKey(t, algHS256))
+	query := url.Values{"region": {"europe"}, "sort": {"-population"}}
+	keys, err := parseSortKeys(query.Get("sort"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := Country{CCA3: "DEU", Population: 83240525}
+
+	encode := func(c pageCursor) string {
+		t.Helper()
+		token, err := c.encode()
+		if err != nil {
+			t.Fatalf("encoding cursor: %v", err)
+		}
+		return token
+	}
+
+	token := encode(newPageCursor(query, keys, last))
+	if _, err := decodePageCursor(token, query, keys); err != nil {
+		t.Fatalf("decoding a valid cursor: %v", err)
+	}
+
+	payload, sig, _ := strings.Cut(token, ".")
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawSig[0] ^= 0x01
+	flipped := payload + "." + base64.RawURLEncoding.EncodeToString(rawSig)
+
+	expiredCursor := newPageCursor(query, keys, last)
+	expiredCursor.Expires = time.Now().Add(-time.Minute).Unix()
+	expired := encode(expiredCursor)
+
+	current := tokenKeys
+	tokenKeys = &SecretStore{key: newTestSigningKey(t, algHS256)}
+	otherKey := encode(newPageCursor(query, keys, last))
+	tokenKeys = current
+
+	tests := []struct {
+		name  string
+		token string
+		query url.Values
+		want  error
+	}{
+		{"flipped signature byte", flipped, query, errInvalidCursor},
+		{"signed with another key", otherKey, query, errInvalidCursor},
+		{"no signature", payload, query, errInvalidCursor},
+		{"empty", "", query, errInvalidCursor},
+		{"expired", expired, query, errExpiredCursor},
+		{"different filter", token, url.Values{"region": {"asia"}, "sort": {"-population"}}, errCursorMismatch},
+		{"different sort", token, url.Values{"region": {"europe"}, "sort": {"population"}}, errCursorMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePageCursor(tt.token, tt.query, keys); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// The page size and the cursor itself do not change the result set
+	sameSet := url.Values{"region": {"europe"}, "sort": {"-population"}, "limit": {"5"}, "cursor": {token}}
+	if _, err := decodePageCursor(token, sameSet, keys); err != nil {
+		t.Errorf("decoding with another limit: %v", err)
+	}
+}
+
+// TestCountriesFilterRejectsTamperedCursor checks that the filter endpoint answers a tampered
+// cursor, or a cursor combined with a page, with a 400.
+func TestCountriesFilterRejectsTamperedCursor(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	first := getFilterPage(t, "region=europe&limit=3")
+	if first.NextCursor == "" {
+		t.Fatal("first page has no next cursor")
+	}
+	tampered := strings.Replace(first.NextCursor, ".", ".A", 1)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"region=europe&limit=3&cursor=" + url.QueryEscape(first.NextCursor), http.StatusOK},
+		{"region=europe&limit=3&cursor=" + url.QueryEscape(tampered), http.StatusBadRequest},
+		{"region=europe&limit=3&page=2&cursor=" + url.QueryEscape(first.NextCursor), http.StatusBadRequest},
+		{"region=asia&limit=3&cursor=" + url.QueryEscape(first.NextCursor), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?"+tt.query, nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d: %s", tt.query, rec.Code, tt.want, rec.Body)
+		}
+	}
+}
+
+// TestCursorIteratesAcrossCatalogChange checks that following cursors after the dataset changed
+// neither repeats nor skips the countries that were not yet returned.
+func TestCursorIteratesAcrossCatalogChange(t *testing.T) {
+	const query = "region=europe&sort=name&limit=4"
+	countries := testCountries(t)
+	useCatalog(t, countries)
+
+	want := getFilterPage(t, "region=europe&sort=name&limit=100").Country
+	if len(want) < 8 {
+		t.Fatalf("need at least 8 European countries, have %d", len(want))
+	}
+
+	first := getFilterPage(t, query)
+	got := first.Country
+
+	// Between pages, a country already returned disappears and a new one sorts before the cursor
+	changed := []Country{{Name: CountryName{Common: "Aaland"}, CCA3: "ZZA", Region: "Europe"}}
+	for _, country := range countries {
+		if country.Name.Common != want[0] {
+			changed = append(changed, country)
+		}
+	}
+	useCatalog(t, changed)
+
+	cursor := first.NextCursor
+	for pages := 0; cursor != ""; pages++ {
+		if pages > len(want) {
+			t.Fatal("cursor iteration does not terminate")
+		}
+		page := getFilterPage(t, query+"&cursor="+url.QueryEscape(cursor))
+		got = append(got, page.Country...)
+		cursor = page.NextCursor
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("iterated %v, want %v", got, want)
+	}
+}
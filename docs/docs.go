@@ -16,54 +16,62 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth": {
-            "post": {
-                "description": "Authenticate user with credentials and generate access token",
-                "consumes": [
-                    "application/json"
+        "/admin/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "List the keys in the keyring: the active key that signs new tokens, superseded keys still verifying older tokens, and expired or retired keys. Requires an admin user and KEYRING_FILE.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/yaml"
                 ],
                 "tags": [
-                    "authentication"
+                    "admin"
                 ],
-                "summary": "Authenticate user and generate access token",
+                "summary": "List signing keys",
                 "parameters": [
                     {
-                        "description": "User credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/main.Credentials"
-                        }
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JWT token",
+                        "description": "Keys, oldest first",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/main.KeyInfo"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "401": {
+                        "description": "Missing or invalid auth token",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The server is not using a keyring",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error generating token",
+                        "description": "Error reading the keyring",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
@@ -71,25 +79,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/countries": {
-            "get": {
+        "/admin/keys/rotate": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all countries - For testing purpose only. Because it retrieves all the details it will take some time to load (8-12 seconds)",
+                "description": "Generate a new active signing key. The previous key keeps verifying tokens until they have all expired (24 hours), unless it is retired earlier. Requires an admin user and KEYRING_FILE.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/yaml"
                 ],
                 "tags": [
-                    "countries"
+                    "admin"
                 ],
-                "summary": "Retrieve a list of all countries details",
+                "summary": "Rotate the signing key",
                 "parameters": [
+                    {
+                        "description": "Algorithm of the new key",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.RotateKeyRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "JWT token",
@@ -100,63 +118,68 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "country data",
+                        "description": "The new active key",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/main.KeyInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or algorithm",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid auth token",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The server is not using a keyring",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error rotating the key",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/countries/filter": {
-            "get": {
+        "/admin/keys/{kid}/retire": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a filtered and sorted list of countries based on specified parameters",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stop accepting tokens signed with a superseded key before its verification window ends. The active key cannot be retired. Requires an admin user and KEYRING_FILE.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/yaml"
                 ],
                 "tags": [
-                    "countries"
+                    "admin"
                 ],
-                "summary": "Get a filtered and sorted list of countries",
+                "summary": "Retire a signing key",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Filter countries by population.",
-                        "name": "population",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Filter countries by area.",
-                        "name": "area",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter countries by language.",
-                        "name": "language",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Sort order (asc or desc).",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number for pagination.",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Key ID",
+                        "name": "kid",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -168,25 +191,106 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "paginated list of countries",
+                        "description": "The retired key",
+                        "schema": {
+                            "$ref": "#/definitions/main.KeyInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid auth token",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown key",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The key is active, or the server is not using a keyring",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retiring the key",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth": {
+            "post": {
+                "description": "Authenticate user with credentials and generate access token. The token's sub claim holds the username.\nAfter 5 consecutive failed logins an account is locked for 1 minute, doubling with every further failure up to 1 hour; a successful login resets the count. An admin can also lock an account until it is unlocked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Authenticate user and generate access token",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.Credentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JWT token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid filter or page parameters",
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account is disabled or locked",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error fetching or decoding countries data",
+                        "description": "Error generating token",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No signing key is loaded yet",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
@@ -194,31 +298,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/country": {
+        "/catalog/status": {
+            "get": {
+                "description": "Get whether the in-memory country catalog is loaded, how many countries it holds and the outcome of the last refresh",
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Get the country catalog refresh status",
+                "responses": {
+                    "200": {
+                        "description": "catalog status",
+                        "schema": {
+                            "$ref": "#/definitions/main.CatalogStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "catalog has not been loaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/main.CatalogStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific country",
+                "description": "Retrieve a list of all countries - For testing purpose only. Served from the in-memory country catalog.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "text/csv",
+                    "application/x-protobuf",
+                    "application/geo+json"
                 ],
                 "tags": [
                     "countries"
                 ],
-                "summary": "Get detailed information about a specific country",
+                "summary": "Retrieve a list of all countries details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The name of the country to fetch.",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element.",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xml",
+                            "yaml",
+                            "csv",
+                            "geojson",
+                            "protobuf"
+                        ],
+                        "type": "string",
+                        "description": "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv, application/geo+json or application/x-protobuf).",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "centroid",
+                            "capital"
+                        ],
+                        "type": "string",
+                        "default": "centroid",
+                        "description": "With GeoJSON output, place each feature at the country centroid (latlng) or its capital (capitalInfo.latlng).",
+                        "name": "point",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With CSV output, prefix the body with a UTF-8 byte order mark for Excel.",
+                        "name": "bom",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -232,40 +399,1338 @@ const docTemplate = `{
                     "200": {
                         "description": "country data",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/main.CountryListResponse"
                         }
                     },
                     "400": {
-                        "description": "Error: Please provide a valid country name in the query parameters",
+                        "description": "Invalid fields parameter",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error fetching country data or decoding country data",
+                    "503": {
+                        "description": "Country catalog or signing key is not loaded yet",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.Credentials": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "main.ErrorResponse": {
-            "type": "object"
+        "/countries/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream every country matching the filters as newline-delimited JSON, one country per line, using chunked transfer. Accepts the same filter, sort and fields parameters as /countries/filter, without pagination.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Stream all countries as NDJSON",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dotted field paths to return for each country, e.g. name.common,capital,currencies.*.name,population.",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort keys, prefixed with - for descending order (see /countries/filter).",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum population (inclusive).",
+                        "name": "population_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum population (inclusive).",
+                        "name": "population_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum area in km² (inclusive).",
+                        "name": "area_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum area in km² (inclusive).",
+                        "name": "area_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum population density in people per km² (inclusive).",
+                        "name": "density_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum population density in people per km² (inclusive).",
+                        "name": "density_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by language code or English name.",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether countries must speak any or all of the listed languages.",
+                        "name": "language_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by region.",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by subregion.",
+                        "name": "subregion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by continent.",
+                        "name": "continent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by ISO 4217 currency code.",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by timezone.",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by international calling code.",
+                        "name": "calling_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter landlocked (true) or coastal (false) countries.",
+                        "name": "landlocked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter independent (true) or dependent (false) territories.",
+                        "name": "independent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter UN members (true) or non-members (false).",
+                        "name": "un_member",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "one country per line",
+                        "schema": {
+                            "$ref": "#/definitions/main.Country"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields parameters",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Country catalog or signing key is not loaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/filter": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a filtered and sorted list of countries based on specified parameters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "text/csv",
+                    "application/x-protobuf",
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get a filtered and sorted list of countries",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Minimum population (inclusive).",
+                        "name": "population_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum population (inclusive).",
+                        "name": "population_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum area in km² (inclusive).",
+                        "name": "area_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum area in km² (inclusive).",
+                        "name": "area_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum population density in people per km² (inclusive).",
+                        "name": "density_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum population density in people per km² (inclusive).",
+                        "name": "density_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Deprecated: same as population_min.",
+                        "name": "population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Deprecated: same as area_min.",
+                        "name": "area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by language, as ISO 639-1 (hi) or ISO 639-3 (hin) codes or English names (Hindi), case-insensitive.",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether countries must speak any or all of the listed languages.",
+                        "name": "language_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return countries without language data when filtering by language.",
+                        "name": "include_unknown_languages",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by region, e.g. Europe,Asia.",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by subregion, e.g. Western Europe.",
+                        "name": "subregion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by continent, e.g. South America.",
+                        "name": "continent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by ISO 4217 currency code, e.g. EUR,USD.",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by timezone, e.g. UTC+05:30.",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by international calling code, e.g. +91 or 44.",
+                        "name": "calling_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter landlocked (true) or coastal (false) countries.",
+                        "name": "landlocked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter independent (true) or dependent (false) territories.",
+                        "name": "independent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter UN members (true) or non-members (false).",
+                        "name": "un_member",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sort keys, prefixed with - for descending order: name, official_name, region, capital, population, area, density or gini (e.g. region,-population). Ties are broken by cca3. The legacy values asc and desc sort by name.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of countries per page, at most 100.",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dotted field paths to return for each country instead of its common name, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element.",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous response's next_cursor. Continues after the last country of that response; cannot be combined with page.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xml",
+                            "yaml",
+                            "csv",
+                            "geojson",
+                            "protobuf"
+                        ],
+                        "type": "string",
+                        "description": "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv, application/geo+json or application/x-protobuf).",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "centroid",
+                            "capital"
+                        ],
+                        "type": "string",
+                        "default": "centroid",
+                        "description": "With GeoJSON output, place each feature at the country centroid (latlng) or its capital (capitalInfo.latlng).",
+                        "name": "point",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With CSV output, prefix the body with a UTF-8 byte order mark for Excel.",
+                        "name": "bom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "paginated list of countries",
+                        "schema": {
+                            "$ref": "#/definitions/main.CountryFilterResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the first, prev, next and last pages"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor for the next page, when there is one (CSV responses)"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching countries (CSV responses)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or page parameters, a range whose min is greater than its max, or an invalid, tampered or expired cursor",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Country catalog or signing key is not loaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/country": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific country by name, or about several countries at once by ISO 3166 / IOC codes.\nName matches are ranked best first and carry a score between 0 and 1.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "text/csv",
+                    "application/x-protobuf"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get detailed information about a specific country",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the country to fetch.",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "official",
+                            "prefix",
+                            "substring",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "default": "substring",
+                        "description": "Name match mode: exact (common name), official (official name), prefix, substring or fuzzy (edit distance across names, translations and alt spellings).",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element.",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated cca2, cca3, ccn3 or cioc codes to fetch (e.g. US,FR,IN). Used when name is not provided.",
+                        "name": "codes",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xml",
+                            "yaml",
+                            "csv",
+                            "protobuf"
+                        ],
+                        "type": "string",
+                        "description": "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv or application/x-protobuf).",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With CSV output, prefix the body with a UTF-8 byte order mark for Excel.",
+                        "name": "bom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "country data, ranked by match score",
+                        "schema": {
+                            "$ref": "#/definitions/main.CountryMatchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Please provide a valid country name or match mode in the query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No country matches the name, or unknown country codes",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Country catalog or signing key is not loaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/country/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a country by its exact ISO 3166-1 alpha-2 (cca2), alpha-3 (cca3), numeric (ccn3) or IOC (cioc) code, case-insensitively",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/xml",
+                    "application/yaml",
+                    "text/csv",
+                    "application/x-protobuf"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get a country by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The cca2, cca3, ccn3 or cioc code of the country (e.g. IN, IND, 356).",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dotted field paths to return, e.g. name.common,capital,currencies.*.name,population. * matches every map key or list element.",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xml",
+                            "yaml",
+                            "csv",
+                            "protobuf"
+                        ],
+                        "type": "string",
+                        "description": "Response format; overrides the Accept header (application/json, application/xml, application/yaml, text/csv or application/x-protobuf).",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With CSV output, prefix the body with a UTF-8 byte order mark for Excel.",
+                        "name": "bom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "country data",
+                        "schema": {
+                            "$ref": "#/definitions/main.CountryDetailsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid country code",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown country code",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Country catalog or signing key is not loaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Run a GraphQL query over the country catalog. The schema has Country, Currency, Language and Region types; Country.borders resolves to the neighbouring Country objects, and the countries query takes the same filters as /countries/filter.\nQueries may nest at most 8 fields deep and have an estimated complexity of at most 2000 (every field costs 1, fields under a list count 10 times).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Query countries with GraphQL",
+                "parameters": [
+                    {
+                        "description": "GraphQL query, optional operationName and variables",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.graphQLRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL result with data and any field errors",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, a query that does not validate or one over the depth or complexity limits",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.CapitalInfo": {
+            "type": "object",
+            "properties": {
+                "latlng": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "main.Car": {
+            "type": "object",
+            "properties": {
+                "side": {
+                    "type": "string"
+                },
+                "signs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.CatalogStatus": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "integer"
+                },
+                "last_attempt": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_success": {
+                    "type": "string"
+                },
+                "loaded": {
+                    "type": "boolean"
+                },
+                "next_refresh": {
+                    "type": "string"
+                },
+                "refresh_interval": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Country": {
+            "type": "object",
+            "properties": {
+                "altSpellings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "area": {
+                    "type": "number"
+                },
+                "borders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capital": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capitalInfo": {
+                    "$ref": "#/definitions/main.CapitalInfo"
+                },
+                "car": {
+                    "$ref": "#/definitions/main.Car"
+                },
+                "cca2": {
+                    "type": "string"
+                },
+                "cca3": {
+                    "type": "string"
+                },
+                "ccn3": {
+                    "type": "string"
+                },
+                "cioc": {
+                    "type": "string"
+                },
+                "coatOfArms": {
+                    "$ref": "#/definitions/main.Images"
+                },
+                "continents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.Currency"
+                    }
+                },
+                "demonyms": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.Demonym"
+                    }
+                },
+                "fifa": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "flags": {
+                    "$ref": "#/definitions/main.Images"
+                },
+                "gini": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "idd": {
+                    "$ref": "#/definitions/main.IDD"
+                },
+                "independent": {
+                    "type": "boolean"
+                },
+                "landlocked": {
+                    "type": "boolean"
+                },
+                "languages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "latlng": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "maps": {
+                    "$ref": "#/definitions/main.Maps"
+                },
+                "name": {
+                    "$ref": "#/definitions/main.CountryName"
+                },
+                "population": {
+                    "type": "integer"
+                },
+                "postalCode": {
+                    "$ref": "#/definitions/main.PostalCode"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "startOfWeek": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subregion": {
+                    "type": "string"
+                },
+                "timezones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tld": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.NativeName"
+                    }
+                },
+                "unMember": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "main.CountryDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "country_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Country"
+                    }
+                }
+            }
+        },
+        "main.CountryFilterResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.CountryListResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Country"
+                    }
+                }
+            }
+        },
+        "main.CountryMatch": {
+            "type": "object",
+            "properties": {
+                "altSpellings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "area": {
+                    "type": "number"
+                },
+                "borders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capital": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "capitalInfo": {
+                    "$ref": "#/definitions/main.CapitalInfo"
+                },
+                "car": {
+                    "$ref": "#/definitions/main.Car"
+                },
+                "cca2": {
+                    "type": "string"
+                },
+                "cca3": {
+                    "type": "string"
+                },
+                "ccn3": {
+                    "type": "string"
+                },
+                "cioc": {
+                    "type": "string"
+                },
+                "coatOfArms": {
+                    "$ref": "#/definitions/main.Images"
+                },
+                "continents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.Currency"
+                    }
+                },
+                "demonyms": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.Demonym"
+                    }
+                },
+                "fifa": {
+                    "type": "string"
+                },
+                "flag": {
+                    "type": "string"
+                },
+                "flags": {
+                    "$ref": "#/definitions/main.Images"
+                },
+                "gini": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "idd": {
+                    "$ref": "#/definitions/main.IDD"
+                },
+                "independent": {
+                    "type": "boolean"
+                },
+                "landlocked": {
+                    "type": "boolean"
+                },
+                "languages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "latlng": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "maps": {
+                    "$ref": "#/definitions/main.Maps"
+                },
+                "name": {
+                    "$ref": "#/definitions/main.CountryName"
+                },
+                "population": {
+                    "type": "integer"
+                },
+                "postalCode": {
+                    "$ref": "#/definitions/main.PostalCode"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "startOfWeek": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subregion": {
+                    "type": "string"
+                },
+                "timezones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tld": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.NativeName"
+                    }
+                },
+                "unMember": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "main.CountryMatchesResponse": {
+            "type": "object",
+            "properties": {
+                "country_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.CountryMatch"
+                    }
+                }
+            }
+        },
+        "main.CountryName": {
+            "type": "object",
+            "properties": {
+                "common": {
+                    "type": "string"
+                },
+                "nativeName": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/main.NativeName"
+                    }
+                },
+                "official": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Credentials": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Currency": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Demonym": {
+            "type": "object",
+            "properties": {
+                "f": {
+                    "type": "string"
+                },
+                "m": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ErrorResponse": {
+            "type": "object"
+        },
+        "main.IDD": {
+            "type": "object",
+            "properties": {
+                "root": {
+                    "type": "string"
+                },
+                "suffixes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.Images": {
+            "type": "object",
+            "properties": {
+                "alt": {
+                    "type": "string"
+                },
+                "png": {
+                    "type": "string"
+                },
+                "svg": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.KeyInfo": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "not_after": {
+                    "type": "string"
+                },
+                "retired_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "verifying",
+                        "expired",
+                        "retired"
+                    ]
+                },
+                "superseded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Maps": {
+            "type": "object",
+            "properties": {
+                "googleMaps": {
+                    "type": "string"
+                },
+                "openStreetMaps": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.NativeName": {
+            "type": "object",
+            "properties": {
+                "common": {
+                    "type": "string"
+                },
+                "official": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.PostalCode": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "regex": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.RotateKeyRequest": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "description": "Algorithm of the new key: HS256, RS256, ES256 or EdDSA. Defaults to JWT_ALGORITHM.",
+                    "type": "string",
+                    "example": "EdDSA"
+                }
+            }
+        },
+        "main.graphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// CountriesExportHandler streams the filtered country list as newline-delimited JSON.
+// CountriesExportHandler godoc
+// @Summary Stream all countries as NDJSON
+// @Description Stream every country matching the filters as newline-delimited JSON, one country per line, using chunked transfer. Accepts the same filter, sort and fields parameters as /countries/filter, without pagination.
+// @Tags countries
+// @Produce  application/x-ndjson
+// @Param fields query string false "Comma-separated dotted field paths to return for each country, e.g. name.common,capital,currencies.*.name,population."
+// @Param sort query string false "Comma-separated sort keys, prefixed with - for descending order (see /countries/filter)."
+// @Param population_min query number false "Minimum population (inclusive)."
+// @Param population_max query number false "Maximum population (inclusive)."
+// @Param area_min query number false "Minimum area in km² (inclusive)."
+// @Param area_max query number false "Maximum area in km² (inclusive)."
+// @Param density_min query number false "Minimum population density in people per km² (inclusive)."
+// @Param density_max query number false "Maximum population density in people per km² (inclusive)."
+// @Param language query []string false "Filter by language code or English name." collectionFormat(csv)
+// @Param language_match query string false "Whether countries must speak any or all of the listed languages." Enums(any, all) default(any)
+// @Param region query []string false "Filter by region." collectionFormat(csv)
+// @Param subregion query []string false "Filter by subregion." collectionFormat(csv)
+// @Param continent query []string false "Filter by continent." collectionFormat(csv)
+// @Param currency query []string false "Filter by ISO 4217 currency code." collectionFormat(csv)
+// @Param timezone query []string false "Filter by timezone." collectionFormat(csv)
+// @Param calling_code query []string false "Filter by international calling code." collectionFormat(csv)
+// @Param landlocked query boolean false "Filter landlocked (true) or coastal (false) countries."
+// @Param independent query boolean false "Filter independent (true) or dependent (false) territories."
+// @Param un_member query boolean false "Filter UN members (true) or non-members (false)."
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {object} Country "one country per line"
+// @Failure 400 {object} ErrorResponse "Invalid filter, sort or fields parameters"
+// @Failure 503 {object} ErrorResponse "Country catalog or signing key is not loaded yet"
+// @Router /countries/export [get]
+func CountriesExportHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseCountryFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sortKeys, err := parseSortKeys(r.URL.Query().Get("sort"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fields, ok := fieldsFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	countriesData, ok := catalogCountries(w, r)
+	if !ok {
+		return
+	}
+
+	filteredCountries := filterAndSortCountries(countriesData, filter, sortKeys)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	for _, country := range filteredCountries {
+		// Stop early if the client went away
+		if r.Context().Err() != nil {
+			return
+		}
+
+		line, err := fields.apply(country)
+		if err != nil {
+			log.Printf("Error projecting country %s for export: %s", country.CCA3, err)
+			return
+		}
+
+		if err := encoder.Encode(line); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestCountriesExportHandler checks that the export streams one JSON document per line, filtered,
+// sorted and projected like the filter endpoint but without pagination.
+func TestCountriesExportHandler(t *testing.T) {
+	countries := testCountries(t)
+	useCatalog(t, countries)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", len(countries)},
+		{"region=atlantis", 0},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		CountriesExportHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/export?"+tt.query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%q: status %d: %s", tt.query, rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("%q: Content-Type %q", tt.query, ct)
+		}
+
+		var lines []map[string]interface{}
+		scanner := bufio.NewScanner(rec.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatalf("%q: line %d is not a JSON object: %v", tt.query, len(lines)+1, err)
+			}
+			lines = append(lines, line)
+		}
+		if err := scanner.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(lines) != tt.want {
+			t.Errorf("%q: %d lines, want %d", tt.query, len(lines), tt.want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	CountriesExportHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/export?region=europe&sort=-population&fields=cca3,population", nil))
+	var codes []string
+	var populations []float64
+	decoder := json.NewDecoder(rec.Body)
+	for decoder.More() {
+		var line struct {
+			CCA3       string  `json:"cca3"`
+			Population float64 `json:"population"`
+			Region     string  `json:"region"`
+		}
+		if err := decoder.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		if line.Region != "" {
+			t.Errorf("%s: field region was not projected away", line.CCA3)
+		}
+		codes = append(codes, line.CCA3)
+		populations = append(populations, line.Population)
+	}
+	if len(codes) < 2 {
+		t.Fatalf("exported %d European countries", len(codes))
+	}
+	if !slices.IsSortedFunc(populations, func(a, b float64) int { return cmp.Compare(b, a) }) {
+		t.Errorf("populations are not in descending order: %v", populations)
+	}
+}
+
+// TestCountriesExportRejectsInvalidParameters checks that bad parameters fail before streaming.
+func TestCountriesExportRejectsInvalidParameters(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	for _, query := range []string{"fields=nope", "sort=flag", "population_min=-1", "landlocked=perhaps"} {
+		rec := httptest.NewRecorder()
+		CountriesExportHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/export?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: status %d, want 400", query, rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct == "application/x-ndjson" {
+			t.Errorf("%q: error sent as NDJSON", query)
+		}
+	}
+}
+
+// TestCountriesExportStopsForGoneClient checks that the export stops writing once the request
+// context is cancelled.
+func TestCountriesExportStopsForGoneClient(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	CountriesExportHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/export", nil).WithContext(ctx))
+	if rec.Body.Len() != 0 {
+		t.Errorf("wrote %d bytes for a cancelled request", rec.Body.Len())
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// fieldProjection is a parsed fields parameter: a list of dotted paths such as
+// name.common or currencies.*.name. A nil projection keeps every field.
+type fieldProjection [][]string
+
+// countryFieldNames are the top-level JSON fields of Country, used to validate projections.
+var countryFieldNames = jsonFieldNames(reflect.TypeOf(Country{}))
+
+// parseFieldProjection parses a comma-separated list of dotted field paths. A "*" segment
+// matches every key of a map or element of a list.
+func parseFieldProjection(spec string) (fieldProjection, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	var projection fieldProjection
+	for _, raw := range strings.Split(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		path := strings.Split(raw, ".")
+		for _, segment := range path {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field path %q: empty segment", raw)
+			}
+		}
+		if path[0] != "*" && !countryFieldNames[path[0]] {
+			return nil, fmt.Errorf("invalid field path %q: unknown field %q", raw, path[0])
+		}
+
+		projection = append(projection, path)
+	}
+
+	if len(projection) == 0 {
+		return nil, fmt.Errorf("invalid fields parameter: no field paths given")
+	}
+
+	return projection, nil
+}
+
+// fieldsFromRequest parses the fields query parameter, writing a 400 error response if it is invalid.
+func fieldsFromRequest(w http.ResponseWriter, r *http.Request) (fieldProjection, bool) {
+	fields, err := parseFieldProjection(r.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// apply returns the JSON form of v reduced to the projected paths.
+// With a nil projection v is returned unchanged.
+func (p fieldProjection) apply(v interface{}) (interface{}, error) {
+	if p == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	projected, ok := projectValue(generic, p)
+	if !ok {
+		return map[string]interface{}{}, nil
+	}
+	return projected, nil
+}
+
+// applyCountries projects every country, returning the countries themselves with a nil projection.
+func (p fieldProjection) applyCountries(countries []Country) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(countries))
+	for _, country := range countries {
+		projected, err := p.apply(country)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, projected)
+	}
+	return result, nil
+}
+
+// applyMatches projects the country of every match, always keeping its score. With a nil
+// projection the matches are returned whole.
+func (p fieldProjection) applyMatches(matches []CountryMatch) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(matches))
+	for _, match := range matches {
+		if p == nil {
+			result = append(result, match)
+			continue
+		}
+
+		projected, err := p.apply(match.Country)
+		if err != nil {
+			return nil, err
+		}
+		if fields, ok := projected.(map[string]interface{}); ok {
+			fields["score"] = match.Score
+		}
+		result = append(result, projected)
+	}
+	return result, nil
+}
+
+// projectValue keeps the parts of a decoded JSON value selected by paths.
+// It reports false when nothing in value matches.
+func projectValue(value interface{}, paths [][]string) (interface{}, bool) {
+	for _, path := range paths {
+		if len(path) == 0 {
+			return value, true
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for key, child := range v {
+			var tails [][]string
+			for _, path := range paths {
+				if path[0] == key || path[0] == "*" {
+					tails = append(tails, path[1:])
+				}
+			}
+			if len(tails) == 0 {
+				continue
+			}
+			if projected, ok := projectValue(child, tails); ok {
+				result[key] = projected
+			}
+		}
+		return result, len(result) > 0
+
+	case []interface{}:
+		// Paths apply to each element; a leading "*" explicitly selects every element.
+		elementPaths := make([][]string, 0, len(paths))
+		for _, path := range paths {
+			if path[0] == "*" {
+				path = path[1:]
+			}
+			elementPaths = append(elementPaths, path)
+		}
+
+		result := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			if projected, ok := projectValue(element, elementPaths); ok {
+				result = append(result, projected)
+			}
+		}
+		return result, len(result) > 0
+	}
+
+	// A scalar cannot satisfy a path that still has segments left.
+	return nil, false
+}
+
+// jsonFieldNames returns the JSON names of the exported fields of a struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fieldsTestCountry is a country with nested maps and lists to project.
+var fieldsTestCountry = Country{
+	Name:       CountryName{Common: "Switzerland", Official: "Swiss Confederation", NativeName: map[string]NativeName{"fra": {Common: "Suisse", Official: "Confédération suisse"}}},
+	CCA3:       "CHE",
+	Capital:    StringList{"Bern"},
+	Currencies: map[string]Currency{"CHF": {Name: "Swiss franc", Symbol: "Fr."}},
+	Population: 8654622,
+	Car:        Car{Signs: StringList{"CH"}, Side: "right"},
+}
+
+// TestParseFieldProjection checks the accepted and rejected fields parameters.
+func TestParseFieldProjection(t *testing.T) {
+	for _, spec := range []string{"", "   "} {
+		fields, err := parseFieldProjection(spec)
+		if err != nil || fields != nil {
+			t.Errorf("%q: got %v, %v, want a nil projection", spec, fields, err)
+		}
+	}
+
+	fields, err := parseFieldProjection(" name.common, currencies.*.name ,,population")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[["name","common"],["currencies","*","name"],["population"]]`; mustJSON(t, fields) != want {
+		t.Errorf("got %s, want %s", mustJSON(t, fields), want)
+	}
+
+	for _, spec := range []string{"flag_emoji", "name..common", ".name", "name.", ",", "Name"} {
+		if _, err := parseFieldProjection(spec); err == nil {
+			t.Errorf("%q was accepted", spec)
+		}
+	}
+}
+
+// TestFieldProjectionApply checks nested paths, wildcards and paths that select nothing.
+func TestFieldProjectionApply(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"cca3", `{"cca3":"CHE"}`},
+		{"name.common,population", `{"name":{"common":"Switzerland"},"population":8654622}`},
+		{"name.nativeName.*.common", `{"name":{"nativeName":{"fra":{"common":"Suisse"}}}}`},
+		{"currencies.*.name", `{"currencies":{"CHF":{"name":"Swiss franc"}}}`},
+		{"currencies.CHF.symbol", `{"currencies":{"CHF":{"symbol":"Fr."}}}`},
+		{"capital,car.signs", `{"capital":["Bern"],"car":{"signs":["CH"]}}`},
+		{"capital.*", `{"capital":["Bern"]}`},
+		{"name.common.extra", `{}`},
+		{"currencies.EUR", `{}`},
+		{"gini", `{}`},
+		{"*.common", `{"name":{"common":"Switzerland"}}`},
+	}
+	for _, tt := range tests {
+		fields, err := parseFieldProjection(tt.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		projected, err := fields.apply(fieldsTestCountry)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		if got := mustJSON(t, projected); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+
+	var none fieldProjection
+	if projected, _ := none.apply(fieldsTestCountry); projected.(Country).CCA3 != "CHE" {
+		t.Errorf("a nil projection changed the country: %+v", projected)
+	}
+}
+
+// TestFieldProjectionApplyMatches checks that projected name matches keep their score.
+func TestFieldProjectionApplyMatches(t *testing.T) {
+	fields, err := parseFieldProjection("cca3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	projected, err := fields.applyMatches([]CountryMatch{{Country: fieldsTestCountry, Score: 0.75}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, projected), `[{"cca3":"CHE","score":0.75}]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// TestCountriesFilterFields checks that the filter endpoint returns projected objects with fields
+// and rejects an unknown field.
+func TestCountriesFilterFields(t *testing.T) {
+	useCatalog(t, []Country{fieldsTestCountry})
+
+	page := getFilterPage(t, "fields=cca3,capital")
+	if got, want := mustJSON(t, page.Country), `[{"capital":["Bern"],"cca3":"CHE"}]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	rec := httptest.NewRecorder()
+	CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?fields=cca3,unknown", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: status %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+// lockFile does nothing on systems without flock, where only the locking within a process
+// applies. Stop the server while running the user commands there, or use the SQLite store.
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Language match semantics accepted by the language_match parameter.
+const (
+	languageMatchAny = "any"
+	languageMatchAll = "all"
+)
+
+// CountryFilter holds the criteria of the countries filter endpoint. Zero values match every country.
+type CountryFilter struct {
+	Population floatRange
+	Area       floatRange
+	Density    floatRange
+
+	// Languages are ISO 639-1/639-3 codes or English language names. With LanguageMatch "all"
+	// a country must speak every listed language, otherwise any of them. Countries without
+	// language data only match when IncludeUnknownLanguages is set.
+	Languages               []string
+	LanguageMatch           string
+	IncludeUnknownLanguages bool
+
+	// Multi-valued criteria match when any of the listed values matches, case-insensitively.
+	Regions      []string
+	Subregions   []string
+	Continents   []string
+	Currencies   []string
+	Timezones    []string
+	CallingCodes []string
+
+	Landlocked  *bool
+	Independent *bool
+	UNMember    *bool
+}
+
+// floatRange is an inclusive numeric range; a nil bound is open.
+type floatRange struct {
+	Min *float64
+	Max *float64
+}
+
+// set reports whether either bound of the range is set.
+func (r floatRange) set() bool {
+	return r.Min != nil || r.Max != nil
+}
+
+// contains reports whether v lies within the range.
+func (r floatRange) contains(v float64) bool {
+	if r.Min != nil && v < *r.Min {
+		return false
+	}
+	if r.Max != nil && v > *r.Max {
+		return false
+	}
+	return true
+}
+
+// parseCountryFilter reads the filter criteria from the query parameters of the filter endpoint.
+// The legacy population and area parameters are treated as population_min and area_min.
+func parseCountryFilter(query url.Values) (CountryFilter, error) {
+	var filter CountryFilter
+	var err error
+
+	if filter.Population, err = parseRange(query, "population", "population"); err != nil {
+		return CountryFilter{}, err
+	}
+	if filter.Area, err = parseRange(query, "area", "area"); err != nil {
+		return CountryFilter{}, err
+	}
+	if filter.Density, err = parseRange(query, "density", ""); err != nil {
+		return CountryFilter{}, err
+	}
+
+	filter.Languages = parseList(query, "language")
+	filter.LanguageMatch = strings.ToLower(query.Get("language_match"))
+	if filter.LanguageMatch == "" {
+		filter.LanguageMatch = languageMatchAny
+	}
+	if filter.LanguageMatch != languageMatchAny && filter.LanguageMatch != languageMatchAll {
+		return CountryFilter{}, fmt.Errorf("invalid language_match value %q: must be %s or %s", filter.LanguageMatch, languageMatchAny, languageMatchAll)
+	}
+
+	includeUnknown, err := parseBool(query, "include_unknown_languages")
+	if err != nil {
+		return CountryFilter{}, err
+	}
+	filter.IncludeUnknownLanguages = includeUnknown != nil && *includeUnknown
+
+	filter.Regions = parseList(query, "region")
+	filter.Subregions = parseList(query, "subregion")
+	filter.Continents = parseList(query, "continent")
+	filter.Currencies = parseList(query, "currency")
+	for _, tz := range parseList(query, "timezone") {
+		// An unescaped "+" in a query string decodes to a space, so "UTC+05:30" may arrive as "UTC 05:30".
+		filter.Timezones = append(filter.Timezones, strings.Replace(tz, " ", "+", 1))
+	}
+	for _, code := range parseList(query, "calling_code") {
+		filter.CallingCodes = append(filter.CallingCodes, "+"+strings.TrimLeft(code, "+"))
+	}
+
+	if filter.Landlocked, err = parseBool(query, "landlocked"); err != nil {
+		return CountryFilter{}, err
+	}
+	if filter.Independent, err = parseBool(query, "independent"); err != nil {
+		return CountryFilter{}, err
+	}
+	if filter.UNMember, err = parseBool(query, "un_member"); err != nil {
+		return CountryFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// parseList reads a multi-valued parameter given either comma-separated (region=Europe,Asia)
+// or repeated (region=Europe&region=Asia).
+func parseList(query url.Values, param string) []string {
+	var values []string
+	for _, raw := range query[param] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// parseBool parses a boolean query parameter, returning nil if it is absent.
+func parseBool(query url.Values, param string) (*bool, error) {
+	s := query.Get(param)
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s filter value: must be true or false", param)
+	}
+
+	return &v, nil
+}
+
+// parseRange reads the <name>_min and <name>_max parameters. A non-empty legacy parameter
+// is used as the minimum when <name>_min is absent.
+func parseRange(query url.Values, name, legacy string) (floatRange, error) {
+	var r floatRange
+	var err error
+
+	minParam := name + "_min"
+	if query.Get(minParam) == "" && legacy != "" && query.Get(legacy) != "" {
+		minParam = legacy
+	}
+
+	if r.Min, err = parseBound(query, minParam); err != nil {
+		return floatRange{}, err
+	}
+	if r.Max, err = parseBound(query, name+"_max"); err != nil {
+		return floatRange{}, err
+	}
+
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return floatRange{}, fmt.Errorf("invalid %s range: %s_min (%g) is greater than %s_max (%g)", name, name, *r.Min, name, *r.Max)
+	}
+
+	return r, nil
+}
+
+// parseBound parses a single non-negative numeric query parameter, returning nil if it is absent.
+func parseBound(query url.Values, param string) (*float64, error) {
+	s := query.Get(param)
+	if s == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("invalid %s filter value", param)
+	}
+
+	return &v, nil
+}
+
+// Match reports whether the country satisfies every criterion of the filter.
+func (f CountryFilter) Match(country Country) bool {
+	if !f.Population.contains(float64(country.Population)) {
+		return false
+	}
+	if !f.Area.contains(country.Area) {
+		return false
+	}
+	if f.Density.set() {
+		density, ok := country.Density()
+		if !ok || !f.Density.contains(density) {
+			return false
+		}
+	}
+	if len(f.Languages) > 0 && !f.matchLanguages(country) {
+		return false
+	}
+
+	if len(f.Regions) > 0 && !containsFold(f.Regions, country.Region) {
+		return false
+	}
+	if len(f.Subregions) > 0 && !containsFold(f.Subregions, country.Subregion) {
+		return false
+	}
+	if len(f.Continents) > 0 && !anyContainsFold(f.Continents, country.Continents) {
+		return false
+	}
+	if len(f.Currencies) > 0 && !anyContainsFold(f.Currencies, mapKeys(country.Currencies)) {
+		return false
+	}
+	if len(f.Timezones) > 0 && !anyContainsFold(f.Timezones, country.Timezones) {
+		return false
+	}
+	if len(f.CallingCodes) > 0 && !anyContainsFold(f.CallingCodes, country.CallingCodes()) {
+		return false
+	}
+
+	if f.Landlocked != nil && country.Landlocked != *f.Landlocked {
+		return false
+	}
+	if f.Independent != nil && (country.Independent == nil || *country.Independent != *f.Independent) {
+		return false
+	}
+	if f.UNMember != nil && country.UNMember != *f.UNMember {
+		return false
+	}
+
+	return true
+}
+
+// matchLanguages applies the language criteria to the country.
+func (f CountryFilter) matchLanguages(country Country) bool {
+	if len(country.Languages) == 0 {
+		return f.IncludeUnknownLanguages
+	}
+
+	matched := 0
+	for _, wanted := range f.Languages {
+		if speaksLanguage(country, wanted) {
+			matched++
+		}
+	}
+
+	if f.LanguageMatch == languageMatchAll {
+		return matched == len(f.Languages)
+	}
+	return matched > 0
+}
+
+// speaksLanguage reports whether one of the country's languages matches lang, given as an
+// ISO 639-1 code (hi), an ISO 639-3 code (hin) or an English name (Hindi), case-insensitively.
+func speaksLanguage(country Country, lang string) bool {
+	iso3 := ""
+	if base, err := language.ParseBase(lang); err == nil {
+		iso3 = base.ISO3()
+	}
+
+	for code, name := range country.Languages {
+		if strings.EqualFold(code, lang) || strings.EqualFold(name, lang) || strings.EqualFold(code, iso3) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether value equals any of wanted, case-insensitively.
+func containsFold(wanted []string, value string) bool {
+	for _, w := range wanted {
+		if strings.EqualFold(w, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyContainsFold reports whether any of values equals any of wanted, case-insensitively.
+func anyContainsFold(wanted []string, values []string) bool {
+	for _, v := range values {
+		if containsFold(wanted, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapKeys returns the keys of m in no particular order.
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+)
+
+// filterTestCountries is a small dataset with known populations and areas.
+var filterTestCountries = []Country{
+	{CCA3: "CHN", Population: 1402112000, Area: 9706961},
+	{CCA3: "DEU", Population: 83240525, Area: 357114},
+	{CCA3: "ISL", Population: 366425, Area: 103000},
+	{CCA3: "MCO", Population: 39244, Area: 2.02},
+	{CCA3: "ATA", Population: 1000, Area: 14000000},
+	{CCA3: "UMI", Population: 300, Area: 0},
+}
+
+// filterCountries returns the cca3 codes of the countries matching the filter query rawQuery.
+func filterCountries(t *testing.T, rawQuery string, countries []Country) []string {
+	t.Helper()
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, err := parseCountryFilter(values)
+	if err != nil {
+		t.Fatalf("parsing %q: %v", rawQuery, err)
+	}
+
+	var codes []string
+	for _, country := range countries {
+		if filter.Match(country) {
+			codes = append(codes, country.CCA3)
+		}
+	}
+	return codes
+}
+
+// TestCountryFilterRanges checks the population, area and density ranges, their inclusive
+// bounds and the legacy population and area parameters.
+func TestCountryFilterRanges(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"CHN", "DEU", "ISL", "MCO", "ATA", "UMI"}},
+		{"population_min=83240525", []string{"CHN", "DEU"}},
+		{"population_max=39244", []string{"MCO", "ATA", "UMI"}},
+		{"population_min=1000&population_max=366425", []string{"ISL", "MCO", "ATA"}},
+		{"population=1000000", []string{"CHN", "DEU"}},
+		{"population=1000000&population_min=300", []string{"CHN", "DEU", "ISL", "MCO", "ATA", "UMI"}},
+		{"area_min=103000&area_max=357114", []string{"DEU", "ISL"}},
+		{"area=10000000", []string{"ATA"}},
+		{"area_max=0", []string{"UMI"}},
+		{"density_min=1000", []string{"MCO"}},
+		{"density_max=1", []string{"ATA"}},
+		{"density_min=0", []string{"CHN", "DEU", "ISL", "MCO", "ATA"}},
+		{"population_min=&area_max=", []string{"CHN", "DEU", "ISL", "MCO", "ATA", "UMI"}},
+	}
+	for _, tt := range tests {
+		if got := filterCountries(t, tt.query, filterTestCountries); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestParseCountryFilterRejects checks that malformed, negative and inverted ranges are refused.
+func TestParseCountryFilterRejects(t *testing.T) {
+	for _, query := range []string{
+		"population_min=many",
+		"population_min=-1",
+		"population=-5",
+		"area_max=NaN",
+		"area_min=Inf",
+		"density_max=1e400",
+		"population_min=10&population_max=5",
+		"density_min=2&density_max=1",
+	} {
+		values, _ := url.ParseQuery(query)
+		if _, err := parseCountryFilter(values); err == nil {
+			t.Errorf("%q was accepted", query)
+		}
+	}
+}
+
+// TestCountryFilterCategories checks the region, subregion, continent, currency, timezone,
+// calling code and status filters, in both list forms and case-insensitively.
+func TestCountryFilterCategories(t *testing.T) {
+	yes, no := true, false
+	countries := []Country{
+		{
+			CCA3: "IND", Region: "Asia", Subregion: "Southern Asia", Continents: StringList{"Asia"},
+			Currencies: map[string]Currency{"INR": {Name: "Indian rupee"}}, Timezones: StringList{"UTC+05:30"},
+			IDD: IDD{Root: "+9", Suffixes: StringList{"1"}}, Independent: &yes, UNMember: true,
+		},
+		{
+			CCA3: "CHE", Region: "Europe", Subregion: "Western Europe", Continents: StringList{"Europe"},
+			Currencies: map[string]Currency{"CHF": {Name: "Swiss franc"}}, Timezones: StringList{"UTC+01:00"},
+			IDD: IDD{Root: "+4", Suffixes: StringList{"1"}}, Independent: &yes, UNMember: true, Landlocked: true,
+		},
+		{
+			CCA3: "GBR", Region: "Europe", Subregion: "Northern Europe", Continents: StringList{"Europe"},
+			Currencies: map[string]Currency{"GBP": {Name: "British pound"}}, Timezones: StringList{"UTC-08:00", "UTC", "UTC+01:00"},
+			IDD: IDD{Root: "+4", Suffixes: StringList{"4"}}, Independent: &yes, UNMember: true,
+		},
+		{
+			CCA3: "GRL", Region: "Americas", Subregion: "North America", Continents: StringList{"North America"},
+			Currencies: map[string]Currency{"DKK": {Name: "krone"}}, Timezones: StringList{"UTC-04:00", "UTC"},
+			IDD: IDD{Root: "+2", Suffixes: StringList{"99"}}, Independent: &no,
+		},
+		{CCA3: "ATA", Region: "Antarctic", Continents: StringList{"Antarctica"}},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"region=europe", []string{"CHE", "GBR"}},
+		{"region=Europe,Asia", []string{"IND", "CHE", "GBR"}},
+		{"region=Europe&region=ANTARCTIC", []string{"CHE", "GBR", "ATA"}},
+		{"region=Oceania", nil},
+		{"region=,", []string{"IND", "CHE", "GBR", "GRL", "ATA"}},
+		{"subregion=western%20europe", []string{"CHE"}},
+		{"continent=North%20America,Antarctica", []string{"GRL", "ATA"}},
+		{"currency=chf,gbp", []string{"CHE", "GBR"}},
+		{"timezone=UTC", []string{"GBR", "GRL"}},
+		{"timezone=UTC+05:30", []string{"IND"}},
+		{"timezone=UTC%2B01:00", []string{"CHE", "GBR"}},
+		{"calling_code=%2B91", []string{"IND"}},
+		{"calling_code=44,299", []string{"GBR", "GRL"}},
+		{"landlocked=true", []string{"CHE"}},
+		{"landlocked=false&region=europe", []string{"GBR"}},
+		{"independent=false", []string{"GRL"}},
+		{"independent=true&un_member=true", []string{"IND", "CHE", "GBR"}},
+		{"un_member=0", []string{"GRL", "ATA"}},
+	}
+	for _, tt := range tests {
+		if got := filterCountries(t, tt.query, countries); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, query := range []string{"landlocked=maybe", "independent=2", "un_member=yes"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := parseCountryFilter(values); err == nil {
+			t.Errorf("%q was accepted", query)
+		}
+	}
+}
+
+// TestCountryFilterCallingCode checks that a calling code root only matches on its own when it is
+// shared by several suffixes or has none.
+func TestCountryFilterCallingCode(t *testing.T) {
+	countries := []Country{
+		{CCA3: "DEU", IDD: IDD{Root: "+4", Suffixes: StringList{"9"}}},
+		{CCA3: "USA", IDD: IDD{Root: "+1", Suffixes: StringList{"201", "202"}}},
+		{CCA3: "KAZ", IDD: IDD{Root: "+7"}},
+		{CCA3: "ATA"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"calling_code=49", []string{"DEU"}},
+		{"calling_code=4", nil},
+		{"calling_code=1", []string{"USA"}},
+		{"calling_code=%2B1202", []string{"USA"}},
+		{"calling_code=7", []string{"KAZ"}},
+	}
+	for _, tt := range tests {
+		if got := filterCountries(t, tt.query, countries); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestCountryFilterLanguages checks language matching by ISO 639-1 code, ISO 639-3 code and
+// English name, the any/all semantics and countries without language data.
+func TestCountryFilterLanguages(t *testing.T) {
+	countries := []Country{
+		{CCA3: "IND", Languages: map[string]string{"eng": "English", "hin": "Hindi", "tam": "Tamil"}},
+		{CCA3: "CHE", Languages: map[string]string{"fra": "French", "gsw": "Swiss German", "ita": "Italian", "roh": "Romansh"}},
+		{CCA3: "CAN", Languages: map[string]string{"eng": "English", "fra": "French"}},
+		{CCA3: "ATA"},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"language=hi", []string{"IND"}},
+		{"language=HIN", []string{"IND"}},
+		{"language=hindi", []string{"IND"}},
+		{"language=fr", []string{"CHE", "CAN"}},
+		{"language=swiss%20german", []string{"CHE"}},
+		{"language=en,it", []string{"IND", "CHE", "CAN"}},
+		{"language=en&language=fr&language_match=all", []string{"CAN"}},
+		{"language=english,french&language_match=ALL", []string{"CAN"}},
+		{"language=klingon", nil},
+		{"language=hi&include_unknown_languages=true", []string{"IND", "ATA"}},
+		{"language=hi&include_unknown_languages=false", []string{"IND"}},
+		{"language=&language_match=all", []string{"IND", "CHE", "CAN", "ATA"}},
+	}
+	for _, tt := range tests {
+		if got := filterCountries(t, tt.query, countries); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, query := range []string{"language=en&language_match=some", "language=en&include_unknown_languages=perhaps"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := parseCountryFilter(values); err == nil {
+			t.Errorf("%q was accepted", query)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatJSON     = "json"
+	formatXML      = "xml"
+	formatYAML     = "yaml"
+	formatCSV      = "csv"
+	formatGeoJSON  = "geojson"
+	formatProtobuf = "protobuf"
+)
+
+var (
+	// bodyFormats are the general-purpose formats every endpoint, and every error, can be written in.
+	bodyFormats = []string{formatJSON, formatXML, formatYAML}
+	// countryFormats are the formats of the single country endpoints, in order of preference.
+	countryFormats = []string{formatJSON, formatXML, formatYAML, formatCSV, formatProtobuf}
+	// countryListFormats are the formats of the country list endpoints, which can also be mapped.
+	countryListFormats = []string{formatJSON, formatXML, formatYAML, formatCSV, formatGeoJSON, formatProtobuf}
+)
+
+// formatMediaTypes maps each response format to the media types that select it in an Accept header.
+var formatMediaTypes = map[string][]string{
+	formatJSON:     {"application/json"},
+	formatXML:      {"application/xml", "text/xml"},
+	formatYAML:     {"application/yaml", "application/x-yaml", "text/yaml"},
+	formatCSV:      {"text/csv"},
+	formatGeoJSON:  {"application/geo+json"},
+	formatProtobuf: {"application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf"},
+}
+
+// responseFormat picks one of the supported formats from the format query parameter, falling
+// back to the Accept header. JSON is used when neither asks for a supported format.
+func responseFormat(r *http.Request, supported []string) (string, error) {
+	if format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format"))); format != "" {
+		if !containsString(supported, format) {
+			return "", fmt.Errorf("invalid format %q: must be one of %s", format, strings.Join(supported, ", "))
+		}
+		return format, nil
+	}
+
+	return negotiateFormat(r.Header.Get("Accept"), supported), nil
+}
+
+// formatFromRequest reads the response format, writing a 400 error response if it is invalid.
+func formatFromRequest(w http.ResponseWriter, r *http.Request, supported []string) (string, bool) {
+	addVary(w, "Accept")
+
+	format, err := responseFormat(r, supported)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return format, true
+}
+
+// negotiateFormat returns the supported format with the highest quality in an Accept header.
+// Ties go to the media range listed first.
+func negotiateFormat(accept string, supported []string) string {
+	best, bestQuality := formatJSON, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		format, ok := formatForMediaRange(mediaType, supported)
+		if !ok || quality <= bestQuality {
+			continue
+		}
+		best, bestQuality = format, quality
+	}
+
+	return best
+}
+
+// formatForMediaRange returns the preferred format matching a media range such as text/csv or text/*.
+func formatForMediaRange(mediaRange string, supported []string) (string, bool) {
+	for _, format := range supported {
+		for _, mediaType := range formatMediaTypes[format] {
+			if mediaRange == mediaType || mediaRange == "*/*" {
+				return format, true
+			}
+			if typ, sub, _ := strings.Cut(mediaRange, "/"); sub == "*" && strings.HasPrefix(mediaType, typ+"/") {
+				return format, true
+			}
+		}
+	}
+	return "", false
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
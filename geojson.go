@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	pointCentroid = "centroid"
+	pointCapital  = "capital"
+)
+
+// FeatureCollection is a GeoJSON (RFC 7946) feature collection of countries.
+type FeatureCollection struct {
+	Type     string    `json:"type" example:"FeatureCollection"`
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON feature for one country. Geometry is null when the country has no coordinates.
+type Feature struct {
+	Type       string      `json:"type" example:"Feature"`
+	ID         string      `json:"id,omitempty" example:"IND"`
+	Geometry   *Point      `json:"geometry"`
+	Properties interface{} `json:"properties" swaggertype:"object"`
+}
+
+// Point is a GeoJSON point geometry. Coordinates are [longitude, latitude].
+type Point struct {
+	Type        string    `json:"type" example:"Point"`
+	Coordinates []float64 `json:"coordinates" example:"77,20"`
+}
+
+// parsePointSource reads the point query parameter, which selects the coordinates of each feature.
+func parsePointSource(spec string) (string, error) {
+	switch source := strings.ToLower(strings.TrimSpace(spec)); source {
+	case "", pointCentroid:
+		return pointCentroid, nil
+	case pointCapital:
+		return pointCapital, nil
+	default:
+		return "", fmt.Errorf("invalid point %q: must be one of %s, %s", spec, pointCentroid, pointCapital)
+	}
+}
+
+// countryPoint returns the country's centroid or capital as a GeoJSON point, or nil without coordinates.
+func countryPoint(country Country, source string) *Point {
+	latlng := country.LatLng
+	if source == pointCapital {
+		latlng = country.CapitalInfo.LatLng
+	}
+	if len(latlng) < 2 {
+		return nil
+	}
+
+	// restcountries lists latitude first; GeoJSON wants longitude first
+	return &Point{Type: "Point", Coordinates: []float64{latlng[1], latlng[0]}}
+}
+
+// newFeatureCollection returns a feature per country, with the country, or its projected fields, as properties.
+func newFeatureCollection(countries []Country, fields fieldProjection, source string) (FeatureCollection, error) {
+	collection := FeatureCollection{Type: "FeatureCollection", Features: make([]Feature, 0, len(countries))}
+	for _, country := range countries {
+		properties, err := fields.apply(country)
+		if err != nil {
+			return FeatureCollection{}, err
+		}
+
+		collection.Features = append(collection.Features, Feature{
+			Type:       "Feature",
+			ID:         country.CCA3,
+			Geometry:   countryPoint(country, source),
+			Properties: properties,
+		})
+	}
+	return collection, nil
+}
+
+// pointFromRequest reads the point query parameter of a GeoJSON response, writing a 400 error
+// response if it is invalid. Other formats ignore it.
+func pointFromRequest(w http.ResponseWriter, r *http.Request, format string) (string, bool) {
+	if format != formatGeoJSON {
+		return "", true
+	}
+
+	source, err := parsePointSource(r.URL.Query().Get("point"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return source, true
+}
+
+// writeGeoJSONResponse writes the countries as a GeoJSON feature collection, placing each
+// feature at the given point source.
+func writeGeoJSONResponse(w http.ResponseWriter, r *http.Request, countries []Country, fields fieldProjection, source string) {
+	collection, err := newFeatureCollection(countries, fields, source)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "Error projecting country fields")
+		return
+	}
+
+	writeEncoded(w, formatGeoJSON, http.StatusOK, collection)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestParsePointSource checks the accepted values of the point parameter.
+func TestParsePointSource(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", pointCentroid},
+		{"centroid", pointCentroid},
+		{" Capital ", pointCapital},
+	}
+	for _, tt := range tests {
+		if got, err := parsePointSource(tt.spec); err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v, want %q", tt.spec, got, err, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"center", "capital,centroid"} {
+		if _, err := parsePointSource(spec); err == nil {
+			t.Errorf("%q was accepted", spec)
+		}
+	}
+}
+
+// TestNewFeatureCollection checks the coordinate order, the point source, countries without
+// coordinates and projected properties.
+func TestNewFeatureCollection(t *testing.T) {
+	countries := []Country{
+		{CCA3: "NOR", Name: CountryName{Common: "Norway"}, LatLng: []float64{62, 10}, CapitalInfo: CapitalInfo{LatLng: []float64{59.92, 10.75}}},
+		{CCA3: "ATA", Name: CountryName{Common: "Antarctica"}, LatLng: []float64{-90}},
+	}
+	fields, err := parseFieldProjection("name.common")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{pointCentroid, `{"type":"FeatureCollection","features":[` +
+			`{"type":"Feature","id":"NOR","geometry":{"type":"Point","coordinates":[10,62]},"properties":{"name":{"common":"Norway"}}},` +
+			`{"type":"Feature","id":"ATA","geometry":null,"properties":{"name":{"common":"Antarctica"}}}]}`},
+		{pointCapital, `{"type":"FeatureCollection","features":[` +
+			`{"type":"Feature","id":"NOR","geometry":{"type":"Point","coordinates":[10.75,59.92]},"properties":{"name":{"common":"Norway"}}},` +
+			`{"type":"Feature","id":"ATA","geometry":null,"properties":{"name":{"common":"Antarctica"}}}]}`},
+	}
+	for _, tt := range tests {
+		collection, err := newFeatureCollection(countries, fields, tt.source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := mustJSON(t, collection); got != tt.want {
+			t.Errorf("%s:\n got %s\nwant %s", tt.source, got, tt.want)
+		}
+	}
+
+	empty, err := newFeatureCollection(nil, nil, pointCentroid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, empty), `{"type":"FeatureCollection","features":[]}`; got != want {
+		t.Errorf("empty collection: got %s, want %s", got, want)
+	}
+}
+
+// TestGeoJSONResponses checks GeoJSON output on the list endpoints, chosen by format or Accept,
+// and that the single country endpoints do not offer it.
+func TestGeoJSONResponses(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	tests := []struct {
+		name    string
+		target  string
+		accept  string
+		handler http.HandlerFunc
+		status  int
+	}{
+		{"list by format", "/api/v1/countries?format=geojson", "", CountriesListHandler, http.StatusOK},
+		{"filter by Accept", "/api/v1/countries/filter?region=europe&limit=5", "application/geo+json", CountriesFilterListHandler, http.StatusOK},
+		{"filter capital points", "/api/v1/countries/filter?format=geojson&point=capital", "", CountriesFilterListHandler, http.StatusOK},
+		{"details by format", "/api/v1/country?name=norway&format=geojson", "", CountryDetailsHandler, http.StatusBadRequest},
+		{"unknown point", "/api/v1/countries/filter?format=geojson&point=middle", "", CountriesFilterListHandler, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			tt.handler(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/geo+json" {
+				t.Errorf("Content-Type %q", ct)
+			}
+
+			var collection FeatureCollection
+			if err := json.Unmarshal(rec.Body.Bytes(), &collection); err != nil {
+				t.Fatal(err)
+			}
+			if collection.Type != "FeatureCollection" || len(collection.Features) == 0 {
+				t.Fatalf("got %d features in a %q", len(collection.Features), collection.Type)
+			}
+			for _, feature := range collection.Features {
+				if feature.Type != "Feature" || feature.ID == "" {
+					t.Errorf("malformed feature %+v", feature)
+				}
+			}
+		})
+	}
+}
+
+// TestGeoJSONInvalidPoint checks that an invalid point is rejected before any work is done or
+// header set, and that formats other than GeoJSON ignore it.
+func TestGeoJSONInvalidPoint(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	tests := []struct {
+		target  string
+		handler http.HandlerFunc
+		status  int
+	}{
+		{"/api/v1/countries?format=geojson&point=middle", CountriesListHandler, http.StatusBadRequest},
+		{"/api/v1/countries/filter?format=geojson&page_size=1&point=middle", CountriesFilterListHandler, http.StatusBadRequest},
+		{"/api/v1/countries/filter?page_size=1&point=middle", CountriesFilterListHandler, http.StatusOK},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d: %s", tt.target, rec.Code, tt.status, rec.Body)
+		}
+		if tt.status != http.StatusOK && (rec.Header().Get("Link") != "" || rec.Header().Get("X-Total-Count") != "") {
+			t.Errorf("%s: pagination headers set on an error: %v", tt.target, rec.Header())
+		}
+	}
+}
+
+// TestGeoJSONFilterPagination checks that a GeoJSON filter page carries its pagination metadata
+// in headers and that the next cursor continues it.
+func TestGeoJSONFilterPagination(t *testing.T) {
+	useCatalog(t, testCountries(t))
+
+	get := func(query string) (*httptest.ResponseRecorder, []string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?format=geojson&"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%q: status %d: %s", query, rec.Code, rec.Body)
+		}
+		var collection FeatureCollection
+		if err := json.Unmarshal(rec.Body.Bytes(), &collection); err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, feature := range collection.Features {
+			ids = append(ids, feature.ID)
+		}
+		return rec, ids
+	}
+
+	all := countryCodes(testCountries(t))
+	first, firstIDs := get("sort=name&limit=5")
+	if first.Header().Get("X-Total-Count") == "" || first.Header().Get("Link") == "" {
+		t.Errorf("missing pagination headers: %v", first.Header())
+	}
+	cursor := first.Header().Get("X-Next-Cursor")
+	if cursor == "" || len(firstIDs) != 5 {
+		t.Fatalf("first page: %d features, cursor %q", len(firstIDs), cursor)
+	}
+
+	_, secondIDs := get("sort=name&limit=5&cursor=" + cursor)
+	if len(secondIDs) != 5 || slices.Contains(firstIDs, secondIDs[0]) {
+		t.Errorf("second page %v repeats the first %v", secondIDs, firstIDs)
+	}
+	for _, id := range append(firstIDs, secondIDs...) {
+		if !slices.Contains(all, id) {
+			t.Errorf("unknown feature %s", id)
+		}
+	}
+}
@@ -0,0 +1,686 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const (
+	// maxGraphQLDepth is the deepest field nesting a query may select.
+	maxGraphQLDepth = 8
+	// maxGraphQLComplexity is the highest estimated cost a query may have. Every field costs 1,
+	// and the cost of the fields selected under a list is multiplied by graphQLListFactor.
+	maxGraphQLComplexity = 2000
+	// graphQLListFactor is the number of elements a list field is assumed to return.
+	graphQLListFactor = 10
+)
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// countryRegion is the source of the Region GraphQL type.
+type countryRegion struct {
+	Name      string
+	Countries []Country
+}
+
+// countryPage is the source of the CountryPage GraphQL type.
+type countryPage struct {
+	Countries  []Country `json:"countries"`
+	Total      int       `json:"total"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"pageSize"`
+	TotalPages int       `json:"totalPages"`
+	NextCursor string    `json:"nextCursor"`
+}
+
+// graphQLFilterArgs maps the filter arguments of the countries query to the query parameters
+// of the filter endpoint.
+var graphQLFilterArgs = []struct {
+	name, param string
+	typ         graphql.Input
+	description string
+}{
+	{"populationMin", "population_min", graphql.Float, "Minimum population (inclusive)."},
+	{"populationMax", "population_max", graphql.Float, "Maximum population (inclusive)."},
+	{"areaMin", "area_min", graphql.Float, "Minimum area in km² (inclusive)."},
+	{"areaMax", "area_max", graphql.Float, "Maximum area in km² (inclusive)."},
+	{"densityMin", "density_min", graphql.Float, "Minimum population density in people per km² (inclusive)."},
+	{"densityMax", "density_max", graphql.Float, "Maximum population density in people per km² (inclusive)."},
+	{"language", "language", graphql.NewList(graphql.String), "Language codes or English names."},
+	{"languageMatch", "language_match", graphql.String, "Whether countries must speak any (default) or all of the languages."},
+	{"includeUnknownLanguages", "include_unknown_languages", graphql.Boolean, "Also return countries without language data."},
+	{"region", "region", graphql.NewList(graphql.String), "Regions, e.g. Europe."},
+	{"subregion", "subregion", graphql.NewList(graphql.String), "Subregions, e.g. Western Europe."},
+	{"continent", "continent", graphql.NewList(graphql.String), "Continents, e.g. South America."},
+	{"currency", "currency", graphql.NewList(graphql.String), "ISO 4217 currency codes."},
+	{"timezone", "timezone", graphql.NewList(graphql.String), "Timezones, e.g. UTC+05:30."},
+	{"callingCode", "calling_code", graphql.NewList(graphql.String), "International calling codes, e.g. +91."},
+	{"landlocked", "landlocked", graphql.Boolean, "Landlocked (true) or coastal (false) countries."},
+	{"independent", "independent", graphql.Boolean, "Independent (true) or dependent (false) territories."},
+	{"unMember", "un_member", graphql.Boolean, "UN members (true) or non-members (false)."},
+	{"sort", "sort", graphql.String, "Comma-separated sort keys, prefixed with - for descending order, e.g. region,-population."},
+	{"page", "page", graphql.Int, "Page number."},
+	{"limit", "limit", graphql.Int, "Countries per page, at most 100."},
+	{"cursor", "cursor", graphql.String, "nextCursor from a previous page; cannot be combined with page."},
+}
+
+var nativeNameType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "NativeName",
+	Description: "A common/official name pair in one language.",
+	Fields: graphql.Fields{
+		"language": &graphql.Field{Type: graphql.String, Description: "ISO 639-3 language code."},
+		"common":   &graphql.Field{Type: graphql.String},
+		"official": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var countryNameType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "CountryName",
+	Description: "The common, official and native names of a country.",
+	Fields: graphql.Fields{
+		"common":   &graphql.Field{Type: graphql.String},
+		"official": &graphql.Field{Type: graphql.String},
+		"nativeName": &graphql.Field{
+			Type: graphql.NewList(nativeNameType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				names := p.Source.(CountryName).NativeName
+				languages := mapKeys(names)
+				sort.Strings(languages)
+
+				result := make([]map[string]interface{}, 0, len(languages))
+				for _, lang := range languages {
+					result = append(result, map[string]interface{}{
+						"language": lang,
+						"common":   names[lang].Common,
+						"official": names[lang].Official,
+					})
+				}
+				return result, nil
+			},
+		},
+	},
+})
+
+var currencyType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Currency",
+	Description: "A currency used by a country.",
+	Fields: graphql.Fields{
+		"code":   &graphql.Field{Type: graphql.String, Description: "ISO 4217 currency code."},
+		"name":   &graphql.Field{Type: graphql.String},
+		"symbol": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var languageType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Language",
+	Description: "A language spoken in a country.",
+	Fields: graphql.Fields{
+		"code": &graphql.Field{Type: graphql.String, Description: "ISO 639-3 language code."},
+		"name": &graphql.Field{Type: graphql.String, Description: "English name."},
+	},
+})
+
+var imagesType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Images",
+	Description: "PNG/SVG image links such as flags or coats of arms.",
+	Fields: graphql.Fields{
+		"png": &graphql.Field{Type: graphql.String},
+		"svg": &graphql.Field{Type: graphql.String},
+		"alt": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var mapsType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Maps",
+	Description: "Links to the country on external map services.",
+	Fields: graphql.Fields{
+		"googleMaps":     &graphql.Field{Type: graphql.String},
+		"openStreetMaps": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var capitalInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "CapitalInfo",
+	Description: "The coordinates of the capital city.",
+	Fields: graphql.Fields{
+		"latlng": &graphql.Field{Type: graphql.NewList(graphql.Float)},
+	},
+})
+
+var countryType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Country",
+	Description: "A restcountries v3.1 country. Field names match the JSON API.",
+	Fields: graphql.Fields{
+		"name":         &graphql.Field{Type: countryNameType},
+		"tld":          &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"cca2":         &graphql.Field{Type: graphql.String},
+		"ccn3":         &graphql.Field{Type: graphql.String},
+		"cca3":         &graphql.Field{Type: graphql.String},
+		"cioc":         &graphql.Field{Type: graphql.String},
+		"independent":  &graphql.Field{Type: graphql.Boolean},
+		"status":       &graphql.Field{Type: graphql.String},
+		"unMember":     &graphql.Field{Type: graphql.Boolean},
+		"capital":      &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"altSpellings": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"subregion":    &graphql.Field{Type: graphql.String},
+		"latlng":       &graphql.Field{Type: graphql.NewList(graphql.Float)},
+		"landlocked":   &graphql.Field{Type: graphql.Boolean},
+		"borderCodes": &graphql.Field{
+			Type:        graphql.NewList(graphql.String),
+			Description: "cca3 codes of the bordering countries.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(Country).Borders, nil
+			},
+		},
+		"area":        &graphql.Field{Type: graphql.Float, Description: "Area in km²."},
+		"flag":        &graphql.Field{Type: graphql.String},
+		"maps":        &graphql.Field{Type: mapsType},
+		"population":  &graphql.Field{Type: graphql.Int},
+		"fifa":        &graphql.Field{Type: graphql.String},
+		"timezones":   &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"continents":  &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"flags":       &graphql.Field{Type: imagesType},
+		"coatOfArms":  &graphql.Field{Type: imagesType},
+		"startOfWeek": &graphql.Field{Type: graphql.String},
+		"capitalInfo": &graphql.Field{Type: capitalInfoType},
+		"density": &graphql.Field{
+			Type:        graphql.Float,
+			Description: "Population per km², or null when the area is unknown.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if density, ok := p.Source.(Country).Density(); ok {
+					return density, nil
+				}
+				return nil, nil
+			},
+		},
+		"gini": &graphql.Field{
+			Type:        graphql.Float,
+			Description: "Most recent Gini coefficient, or null when unknown.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if gini, ok := p.Source.(Country).LatestGini(); ok {
+					return gini, nil
+				}
+				return nil, nil
+			},
+		},
+		"callingCodes": &graphql.Field{
+			Type:        graphql.NewList(graphql.String),
+			Description: "International calling codes, e.g. +91.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(Country).CallingCodes(), nil
+			},
+		},
+		"currencies": &graphql.Field{
+			Type: graphql.NewList(currencyType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				currencies := p.Source.(Country).Currencies
+				codes := mapKeys(currencies)
+				sort.Strings(codes)
+
+				result := make([]map[string]interface{}, 0, len(codes))
+				for _, code := range codes {
+					result = append(result, map[string]interface{}{
+						"code":   code,
+						"name":   currencies[code].Name,
+						"symbol": currencies[code].Symbol,
+					})
+				}
+				return result, nil
+			},
+		},
+		"languages": &graphql.Field{
+			Type: graphql.NewList(languageType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				languages := p.Source.(Country).Languages
+				codes := mapKeys(languages)
+				sort.Strings(codes)
+
+				result := make([]map[string]interface{}, 0, len(codes))
+				for _, code := range codes {
+					result = append(result, map[string]interface{}{"code": code, "name": languages[code]})
+				}
+				return result, nil
+			},
+		},
+	},
+})
+
+var regionType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Region",
+	Description: "A world region and the countries in it.",
+	Fields: graphql.Fields{
+		"name": &graphql.Field{Type: graphql.String},
+		"subregions": &graphql.Field{
+			Type: graphql.NewList(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				seen := make(map[string]bool)
+				var subregions []string
+				for _, country := range p.Source.(countryRegion).Countries {
+					if country.Subregion != "" && !seen[country.Subregion] {
+						seen[country.Subregion] = true
+						subregions = append(subregions, country.Subregion)
+					}
+				}
+				sort.Strings(subregions)
+				return subregions, nil
+			},
+		},
+		"countries": &graphql.Field{
+			Type: graphql.NewList(countryType),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(countryRegion).Countries, nil
+			},
+		},
+	},
+})
+
+var countryPageType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "CountryPage",
+	Description: "One page of filtered countries.",
+	Fields: graphql.Fields{
+		"countries":  &graphql.Field{Type: graphql.NewList(countryType)},
+		"total":      &graphql.Field{Type: graphql.Int},
+		"page":       &graphql.Field{Type: graphql.Int, Description: "Page number, 0 when paging by cursor."},
+		"pageSize":   &graphql.Field{Type: graphql.Int},
+		"totalPages": &graphql.Field{Type: graphql.Int},
+		"nextCursor": &graphql.Field{Type: graphql.String, Description: "Cursor for the next page, or empty on the last page."},
+	},
+})
+
+// graphQLSchema is the schema served by GraphQLHandler.
+var graphQLSchema = newGraphQLSchema()
+
+// newGraphQLSchema builds the GraphQL schema over the country catalog.
+func newGraphQLSchema() graphql.Schema {
+	// Country and Region refer to each other, so these fields are added once both types exist.
+	countryType.AddFieldConfig("borders", &graphql.Field{
+		Type:        graphql.NewList(countryType),
+		Description: "The bordering countries.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var borders []Country
+			for _, code := range p.Source.(Country).Borders {
+				country, err := catalog.ByCode(code)
+				if errors.Is(err, errCatalogNotLoaded) {
+					return nil, err
+				}
+				if err == nil {
+					borders = append(borders, country)
+				}
+			}
+			return borders, nil
+		},
+	})
+	countryType.AddFieldConfig("region", &graphql.Field{
+		Type: regionType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			region := p.Source.(Country).Region
+			if region == "" {
+				return nil, nil
+			}
+			return regionsFromContext(p.Context).find(region)
+		},
+	})
+
+	filterArgs := graphql.FieldConfigArgument{}
+	for _, arg := range graphQLFilterArgs {
+		filterArgs[arg.name] = &graphql.ArgumentConfig{Type: arg.typ, Description: arg.description}
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"country": &graphql.Field{
+				Type:        countryType,
+				Description: "A country by cca2, cca3, ccn3 or cioc code, or null if there is none.",
+				Args: graphql.FieldConfigArgument{
+					"code": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					code := strings.ToUpper(strings.TrimSpace(p.Args["code"].(string)))
+					if !isCountryCode(code) {
+						return nil, fmt.Errorf("Invalid country code %q: expected a cca2, cca3, ccn3 or cioc code", code)
+					}
+
+					country, err := catalog.ByCode(code)
+					if errors.Is(err, errCountryNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return country, nil
+				},
+			},
+			"countries": &graphql.Field{
+				Type:        countryPageType,
+				Description: "One page of countries matching the filters, like /countries/filter.",
+				Args:        filterArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					query, err := parseCountryFilterQuery(graphQLFilterValues(p.Args))
+					if err != nil {
+						return nil, err
+					}
+
+					countries, err := catalog.Countries()
+					if err != nil {
+						return nil, err
+					}
+
+					result, err := query.run(countries)
+					if err != nil {
+						return nil, err
+					}
+					return countryPage{
+						Countries:  result.countries,
+						Total:      result.page.Total,
+						Page:       result.page.Page,
+						PageSize:   result.page.Size,
+						TotalPages: result.page.TotalPages,
+						NextCursor: result.nextCursor,
+					}, nil
+				},
+			},
+			"regions": &graphql.Field{
+				Type:        graphql.NewList(regionType),
+				Description: "Every region, in alphabetical order.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return regionsFromContext(p.Context).all()
+				},
+			},
+			"region": &graphql.Field{
+				Type:        regionType,
+				Description: "A region by name, case-insensitively, or null if there is none.",
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return regionsFromContext(p.Context).find(p.Args["name"].(string))
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		panic(fmt.Sprintf("invalid GraphQL schema: %s", err))
+	}
+	return schema
+}
+
+// graphQLFilterValues converts the filter arguments of the countries query to filter endpoint query parameters.
+func graphQLFilterValues(args map[string]interface{}) url.Values {
+	values := url.Values{}
+	for _, arg := range graphQLFilterArgs {
+		switch v := args[arg.name].(type) {
+		case float64:
+			values.Set(arg.param, strconv.FormatFloat(v, 'f', -1, 64))
+		case int:
+			values.Set(arg.param, strconv.Itoa(v))
+		case bool:
+			values.Set(arg.param, strconv.FormatBool(v))
+		case string:
+			values.Set(arg.param, v)
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					values.Add(arg.param, s)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// countryRegions groups the catalog's countries by region, in alphabetical order.
+func countryRegions() ([]countryRegion, error) {
+	countries, err := catalog.Countries()
+	if err != nil {
+		return nil, err
+	}
+	sortCountries(countries, defaultSortKeys)
+
+	byName := make(map[string]*countryRegion)
+	var regions []*countryRegion
+	for _, country := range countries {
+		if country.Region == "" {
+			continue
+		}
+		region, ok := byName[country.Region]
+		if !ok {
+			region = &countryRegion{Name: country.Region}
+			byName[country.Region] = region
+			regions = append(regions, region)
+		}
+		region.Countries = append(region.Countries, country)
+	}
+
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Name < regions[j].Name })
+	result := make([]countryRegion, 0, len(regions))
+	for _, region := range regions {
+		result = append(result, *region)
+	}
+	return result, nil
+}
+
+// regionIndex holds the regions of one GraphQL request, grouped on first use, so resolving the
+// region of every country in a list does not regroup the whole catalog each time.
+type regionIndex struct {
+	once    sync.Once
+	regions []countryRegion
+	byName  map[string]int
+	err     error
+}
+
+// regionIndexKey is the context key of the regionIndex of a GraphQL request.
+type regionIndexKey struct{}
+
+// regionsFromContext returns the regionIndex of the request, or a new one outside a request.
+func regionsFromContext(ctx context.Context) *regionIndex {
+	if index, ok := ctx.Value(regionIndexKey{}).(*regionIndex); ok {
+		return index
+	}
+	return &regionIndex{}
+}
+
+// all returns every region, in alphabetical order.
+func (idx *regionIndex) all() ([]countryRegion, error) {
+	idx.once.Do(func() {
+		idx.regions, idx.err = countryRegions()
+		idx.byName = make(map[string]int, len(idx.regions))
+		for i, region := range idx.regions {
+			idx.byName[strings.ToLower(region.Name)] = i
+		}
+	})
+	return idx.regions, idx.err
+}
+
+// find returns the region with the given name, case-insensitively, or nil if there is none.
+func (idx *regionIndex) find(name string) (interface{}, error) {
+	if _, err := idx.all(); err != nil {
+		return nil, err
+	}
+	i, ok := idx.byName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, nil
+	}
+	return idx.regions[i], nil
+}
+
+// checkGraphQLLimits rejects queries nested deeper than maxGraphQLDepth or costlier than
+// maxGraphQLComplexity. Introspection fields are not counted. Queries that do not parse are
+// left for graphql.Do to report.
+func checkGraphQLLimits(schema graphql.Schema, query string) error {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		depth, complexity := selectionCost(operation.SelectionSet, schema.QueryType(), fragments, map[string]bool{})
+		if depth > maxGraphQLDepth {
+			return fmt.Errorf("query is nested %d levels deep, the maximum is %d", depth, maxGraphQLDepth)
+		}
+		if complexity > maxGraphQLComplexity {
+			return fmt.Errorf("query complexity %d exceeds the maximum of %d", complexity, maxGraphQLComplexity)
+		}
+	}
+
+	return nil
+}
+
+// selectionCost returns the depth and estimated cost of a selection set on the given parent type.
+func selectionCost(set *ast.SelectionSet, parent *graphql.Object, fragments map[string]*ast.FragmentDefinition, visiting map[string]bool) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+
+			var child *graphql.Object
+			isList := false
+			if parent != nil {
+				if definition, ok := parent.Fields()[s.Name.Value]; ok {
+					child, isList = unwrapGraphQLType(definition.Type)
+				}
+			}
+
+			childDepth, childComplexity := selectionCost(s.SelectionSet, child, fragments, visiting)
+			if isList {
+				childComplexity *= graphQLListFactor
+			}
+			d, c = childDepth+1, childComplexity+1
+
+		case *ast.InlineFragment:
+			d, c = selectionCost(s.SelectionSet, parent, fragments, visiting)
+
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			d, c = selectionCost(fragment.SelectionSet, parent, fragments, visiting)
+			delete(visiting, name)
+		}
+
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+// unwrapGraphQLType returns the object type behind non-null and list wrappers, and whether
+// there was a list among them.
+func unwrapGraphQLType(t graphql.Type) (*graphql.Object, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, isList
+		default:
+			return nil, isList
+		}
+	}
+}
+
+// GraphQLHandler godoc
+// @Summary Query countries with GraphQL
+// @Description Run a GraphQL query over the country catalog. The schema has Country, Currency, Language and Region types; Country.borders resolves to the neighbouring Country objects, and the countries query takes the same filters as /countries/filter.
+// @Description Queries may nest at most 8 fields deep and have an estimated complexity of at most 2000 (every field costs 1, fields under a list count 10 times).
+// @Tags graphql
+// @Accept  json
+// @Produce  json
+// @Param request body graphQLRequest true "GraphQL query, optional operationName and variables"
+// @Security ApiKeyAuth
+// @Param Authorization header string true "JWT token"
+// @Success 200 {object} map[string]interface{} "GraphQL result with data and any field errors"
+// @Failure 400 {object} map[string]interface{} "Invalid request, a query that does not validate or one over the depth or complexity limits"
+// @Router /graphql [post]
+func GraphQLHandler(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeError(w, r, http.StatusBadRequest, "Invalid variables")
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		writeError(w, r, http.StatusBadRequest, "Error: Please provide a GraphQL query")
+		return
+	}
+
+	if err := checkGraphQLLimits(graphQLSchema, req.Query); err != nil {
+		writeResponse(w, r, http.StatusBadRequest, graphql.Result{
+			Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+		})
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         graphQLSchema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        context.WithValue(r.Context(), regionIndexKey{}, &regionIndex{}),
+	})
+
+	// A query that fails to parse or validate has no data at all
+	status := http.StatusOK
+	if result.Data == nil && result.HasErrors() {
+		status = http.StatusBadRequest
+	}
+	writeResponse(w, r, status, result)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// graphQLTestCountries is a small dataset with borders inside and outside it.
+var graphQLTestCountries = []Country{
+	{CCA2: "NO", CCA3: "NOR", Name: CountryName{Common: "Norway"}, Region: "Europe", Subregion: "Northern Europe", Population: 5379475, Borders: StringList{"FIN", "SWE", "RUS"}},
+	{CCA2: "SE", CCA3: "SWE", Name: CountryName{Common: "Sweden"}, Region: "Europe", Subregion: "Northern Europe", Population: 10353442, Borders: StringList{"FIN", "NOR"}},
+	{CCA2: "FI", CCA3: "FIN", Name: CountryName{Common: "Finland"}, Region: "Europe", Subregion: "Northern Europe", Population: 5530719, Borders: StringList{"NOR", "SWE", "RUS"}},
+	{CCA2: "IN", CCA3: "IND", Name: CountryName{Common: "India"}, Region: "Asia", Subregion: "Southern Asia", Population: 1380004385},
+}
+
+// doGraphQL posts query and variables to GraphQLHandler and returns the status and decoded body.
+func doGraphQL(t *testing.T, query string, variables map[string]interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	body := mustJSON(t, graphQLRequest{Query: query, Variables: variables})
+	rec := httptest.NewRecorder()
+	GraphQLHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(body)))
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decoding %s: %v", rec.Body, err)
+	}
+	return rec.Code, result
+}
+
+// TestGraphQLQueries checks the country, countries, regions and region queries and the
+// borders and region fields of Country.
+func TestGraphQLQueries(t *testing.T) {
+	useCatalog(t, graphQLTestCountries)
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      string
+	}{
+		{
+			"country by cca2", `{ country(code: "no") { cca3 name { common } } }`, nil,
+			`{"country":{"cca3":"NOR","name":{"common":"Norway"}}}`,
+		},
+		{
+			"unknown country", `{ country(code: "DEU") { cca3 } }`, nil,
+			`{"country":null}`,
+		},
+		{
+			"borders skip countries outside the catalog", `{ country(code: "NOR") { borders { cca3 } } }`, nil,
+			`{"country":{"borders":[{"cca3":"FIN"},{"cca3":"SWE"}]}}`,
+		},
+		{
+			"country region", `{ country(code: "IND") { region { name countries { cca3 } } } }`, nil,
+			`{"country":{"region":{"countries":[{"cca3":"IND"}],"name":"Asia"}}}`,
+		},
+		{
+			"countries with filters and variables",
+			`query ($min: Float, $limit: Int) { countries(populationMin: $min, region: ["europe"], sort: "-population", limit: $limit) { countries { cca3 } total page pageSize totalPages } }`,
+			map[string]interface{}{"min": 5400000, "limit": 1},
+			`{"countries":{"countries":[{"cca3":"SWE"}],"page":1,"pageSize":1,"total":2,"totalPages":2}}`,
+		},
+		{
+			"countries without matches", `{ countries(callingCode: ["+999"]) { countries { cca3 } total } }`, nil,
+			`{"countries":{"countries":[],"total":0}}`,
+		},
+		{
+			"regions", `{ regions { name countries { cca3 } } }`, nil,
+			`{"regions":[{"countries":[{"cca3":"IND"}],"name":"Asia"},{"countries":[{"cca3":"FIN"},{"cca3":"NOR"},{"cca3":"SWE"}],"name":"Europe"}]}`,
+		},
+		{
+			"region ignores case", `{ region(name: " EUROPE ") { name } }`, nil,
+			`{"region":{"name":"Europe"}}`,
+		},
+		{
+			"unknown region", `{ region(name: "Oceania") { name } }`, nil,
+			`{"region":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := doGraphQL(t, tt.query, tt.variables)
+			if status != http.StatusOK || result["errors"] != nil {
+				t.Fatalf("status %d: %v", status, result)
+			}
+			if got := mustJSON(t, result["data"]); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGraphQLFieldErrors checks that resolver errors are reported next to partial data.
+func TestGraphQLFieldErrors(t *testing.T) {
+	useCatalog(t, graphQLTestCountries)
+
+	for _, query := range []string{
+		`{ country(code: "NORWAY") { cca3 } }`,
+		`{ countries(populationMin: -1) { total } }`,
+		`{ countries(sort: "flag") { total } }`,
+		`{ countries(page: 2, cursor: "abc") { total } }`,
+	} {
+		status, result := doGraphQL(t, query, nil)
+		if status != http.StatusOK || result["errors"] == nil || result["data"] == nil {
+			t.Errorf("%s: status %d: %v", query, status, result)
+		}
+	}
+}
+
+// TestGraphQLRejects checks the requests GraphQLHandler answers with 400.
+func TestGraphQLRejects(t *testing.T) {
+	useCatalog(t, graphQLTestCountries)
+
+	deep := `{ country(code: "NOR") { borders { borders { borders { borders { borders { borders { borders { cca3 } } } } } } } } }`
+	costly := `{ regions { countries { borders { borders { cca3 name { common official } } } } } }`
+	for _, query := range []string{"", "   ", "{ country", `{ flag }`, `{ country { cca3 } }`, deep, costly} {
+		if status, result := doGraphQL(t, query, nil); status != http.StatusBadRequest {
+			t.Errorf("%q: status %d: %v", query, status, result)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	GraphQLHandler(rec, httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader("{")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid payload: status %d", rec.Code)
+	}
+}
+
+// TestGraphQLGet checks queries and variables passed as GET parameters.
+func TestGraphQLGet(t *testing.T) {
+	useCatalog(t, graphQLTestCountries)
+
+	values := url.Values{
+		"query":     {`query Lookup($code: String!) { country(code: $code) { cca3 } }`},
+		"variables": {`{"code": "SWE"}`},
+	}
+	rec := httptest.NewRecorder()
+	GraphQLHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+values.Encode(), nil))
+	if want := `{"data":{"country":{"cca3":"SWE"}}}`; rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != want {
+		t.Errorf("status %d: got %s, want %s", rec.Code, rec.Body, want)
+	}
+
+	values.Set("variables", "{")
+	rec = httptest.NewRecorder()
+	GraphQLHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+values.Encode(), nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid variables: status %d", rec.Code)
+	}
+}
+
+// TestRegionIndex checks that a request's regions are grouped once, so later catalog changes do
+// not show up halfway through a request.
+func TestRegionIndex(t *testing.T) {
+	useCatalog(t, graphQLTestCountries)
+	index := &regionIndex{}
+	if region, err := index.find("europe"); err != nil || region == nil {
+		t.Fatalf("find: got %v, %v", region, err)
+	}
+
+	useCatalog(t, graphQLTestCountries[3:])
+	region, err := index.find(" EUROPE ")
+	if err != nil || region == nil || len(region.(countryRegion).Countries) != 3 {
+		t.Errorf("find after a catalog change: got %v, %v", region, err)
+	}
+	if region, _ := index.find("Oceania"); region != nil {
+		t.Errorf("unknown region: got %v", region)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"country_assignment_api/countrypb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcPublicMethods are the RPCs that can be called without an auth token.
+var grpcPublicMethods = map[string]bool{
+	countrypb.CountryService_Authenticate_FullMethodName: true,
+}
+
+// countryServer implements the CountryService gRPC API on top of the country catalog.
+type countryServer struct {
+	countrypb.UnimplementedCountryServiceServer
+}
+
+// newGRPCServer returns a gRPC server exposing CountryService, with JWT checks and reflection.
+func newGRPCServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authUnaryInterceptor),
+		grpc.ChainStreamInterceptor(authStreamInterceptor),
+	)
+	countrypb.RegisterCountryServiceServer(server, countryServer{})
+	reflection.Register(server)
+	return server
+}
+
+// authUnaryInterceptor rejects unary calls without a valid token, like AuthMiddleware.
+func authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorizeRPC(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor rejects streaming calls without a valid token, like AuthMiddleware.
+func authStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorizeRPC(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authorizeRPC checks the bearer token in the authorization metadata of a call. Authenticate
+// and the reflection service, used by grpcurl to discover the API, need no token.
+func authorizeRPC(ctx context.Context, method string) error {
+	if grpcPublicMethods[method] || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return status.Error(codes.Unauthenticated, "Missing auth token")
+	}
+
+	_, err := authorizeToken(ctx, strings.TrimPrefix(values[0], "Bearer "))
+	if errors.Is(err, errNoSigningKey) {
+		return errSigningKeyUnavailable
+	}
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "Invalid auth token")
+	}
+
+	return nil
+}
+
+// Authenticate exchanges credentials for a JWT.
+func (countryServer) Authenticate(ctx context.Context, req *countrypb.AuthenticateRequest) (*countrypb.AuthenticateResponse, error) {
+	user, err := authenticate(ctx, users, req.GetUsername(), req.GetPassword())
+	switch {
+	case errors.Is(err, errInvalidCredentials):
+		return nil, status.Error(codes.Unauthenticated, "Invalid credentials")
+	case errors.Is(err, errUserDisabled):
+		return nil, status.Error(codes.PermissionDenied, "Account is disabled")
+	case errors.Is(err, errUserLocked):
+		return nil, status.Error(codes.PermissionDenied, "Account is locked")
+	case err != nil:
+		return nil, status.Error(codes.Internal, "Error checking credentials")
+	}
+
+	token, err := generateToken(user.Username)
+	if errors.Is(err, errNoSigningKey) {
+		return nil, errSigningKeyUnavailable
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Error generating token")
+	}
+	return &countrypb.AuthenticateResponse{Token: token}, nil
+}
+
+// GetCountry looks up a country by code, or returns the best match for a name.
+func (countryServer) GetCountry(ctx context.Context, req *countrypb.GetCountryRequest) (*countrypb.Country, error) {
+	fields, err := parseFieldProjection(req.GetFields())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var country Country
+	switch lookup := req.GetLookup().(type) {
+	case *countrypb.GetCountryRequest_Code:
+		code := strings.ToUpper(strings.TrimSpace(lookup.Code))
+		if !isCountryCode(code) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid country code %q: expected a cca2, cca3, ccn3 or cioc code", code)
+		}
+
+		country, err = catalog.ByCode(code)
+		switch {
+		case errors.Is(err, errCatalogNotLoaded):
+			return nil, errCatalogUnavailable
+		case errors.Is(err, errCountryNotFound):
+			return nil, status.Errorf(codes.NotFound, "Unknown country code(s): %s", code)
+		}
+
+	case *countrypb.GetCountryRequest_Name:
+		countries, err := grpcCatalogCountries()
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := matchCountries(countries, lookup.Name, req.GetMatch())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(matches) == 0 {
+			return nil, status.Errorf(codes.NotFound, "No country matches %q", lookup.Name)
+		}
+		country = matches[0].Country
+
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Error: Please provide a country code or name")
+	}
+
+	messages, err := protoCountries([]Country{country}, fields)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Error projecting country fields")
+	}
+	return messages[0], nil
+}
+
+// ListCountries streams every country in the requested order.
+func (countryServer) ListCountries(req *countrypb.ListCountriesRequest, stream countrypb.CountryService_ListCountriesServer) error {
+	sortKeys, err := parseSortKeys(req.GetSort())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	fields, err := parseFieldProjection(req.GetFields())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	countries, err := grpcCatalogCountries()
+	if err != nil {
+		return err
+	}
+	sortCountries(countries, sortKeys)
+
+	for _, country := range countries {
+		messages, err := protoCountries([]Country{country}, fields)
+		if err != nil {
+			return status.Error(codes.Internal, "Error projecting country fields")
+		}
+		if err := stream.Send(messages[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilterCountries returns one page of filtered countries, accepting the same parameters as
+// the filter endpoint.
+func (countryServer) FilterCountries(ctx context.Context, req *countrypb.FilterCountriesRequest) (*countrypb.CountryFilterResponse, error) {
+	query, err := parseCountryFilterQuery(filterRequestValues(req))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	fields, err := parseFieldProjection(req.GetFields())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	countries, err := grpcCatalogCountries()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := query.run(countries)
+	if errors.Is(err, errNoSigningKey) {
+		return nil, errSigningKeyUnavailable
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Error generating pagination cursor")
+	}
+
+	response, err := result.proto(fields)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Error projecting country fields")
+	}
+	return response, nil
+}
+
+// errCatalogUnavailable is returned by RPCs while the country catalog has not been loaded.
+var errCatalogUnavailable = status.Error(codes.Unavailable, "Error: Country data is not available yet, please retry shortly")
+
+// errSigningKeyUnavailable is returned by RPCs that sign or check tokens or cursors while no
+// signing key has been loaded.
+var errSigningKeyUnavailable = status.Error(codes.Unavailable, "Error: No signing key is loaded, please retry shortly")
+
+// grpcCatalogCountries returns the catalog's countries, or an Unavailable status if they are not loaded.
+func grpcCatalogCountries() ([]Country, error) {
+	countries, err := catalog.Countries()
+	if err != nil {
+		return nil, errCatalogUnavailable
+	}
+	return countries, nil
+}
+
+// filterRequestValues converts a FilterCountriesRequest to the query parameters of the filter endpoint.
+func filterRequestValues(req *countrypb.FilterCountriesRequest) url.Values {
+	values := url.Values{}
+
+	setFloat := func(name string, v *float64) {
+		if v != nil {
+			values.Set(name, strconv.FormatFloat(*v, 'f', -1, 64))
+		}
+	}
+	setBool := func(name string, v *bool) {
+		if v != nil {
+			values.Set(name, strconv.FormatBool(*v))
+		}
+	}
+	setList := func(name string, list []string) {
+		if len(list) > 0 {
+			values.Set(name, strings.Join(list, ","))
+		}
+	}
+	setString := func(name, v string) {
+		if v != "" {
+			values.Set(name, v)
+		}
+	}
+
+	setFloat("population_min", req.PopulationMin)
+	setFloat("population_max", req.PopulationMax)
+	setFloat("area_min", req.AreaMin)
+	setFloat("area_max", req.AreaMax)
+	setFloat("density_min", req.DensityMin)
+	setFloat("density_max", req.DensityMax)
+	setList("language", req.GetLanguage())
+	setString("language_match", req.GetLanguageMatch())
+	if req.GetIncludeUnknownLanguages() {
+		values.Set("include_unknown_languages", "true")
+	}
+	setList("region", req.GetRegion())
+	setList("subregion", req.GetSubregion())
+	setList("continent", req.GetContinent())
+	setList("currency", req.GetCurrency())
+	setList("timezone", req.GetTimezone())
+	setList("calling_code", req.GetCallingCode())
+	setBool("landlocked", req.Landlocked)
+	setBool("independent", req.Independent)
+	setBool("un_member", req.UnMember)
+	setString("sort", req.GetSort())
+	if req.GetPage() != 0 {
+		values.Set("page", fmt.Sprint(req.GetPage()))
+	}
+	if req.GetLimit() != 0 {
+		values.Set("limit", fmt.Sprint(req.GetLimit()))
+	}
+	setString("cursor", req.GetCursor())
+
+	return values
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"slices"
+	"testing"
+
+	"country_assignment_api/countrypb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialTestGRPCServer serves the country service over an in-memory listener for the rest of the
+// test and returns a client connection to it.
+func dialTestGRPCServer(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// TestGRPCAuthInterceptors checks that Authenticate and reflection are public while every
+// other unary and streaming RPC needs a valid bearer token.
+func TestGRPCAuthInterceptors(t *testing.T) {
+	useCatalog(t, testCountries(t))
+	store := useUserStore(t)
+	newTestUser(t, store, "bob")
+	client := countrypb.NewCountryServiceClient(dialTestGRPCServer(t))
+
+	token, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := generateToken("nobody")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          codes.Code
+	}{
+		{"no token", "", codes.Unauthenticated},
+		{"malformed token", "Bearer not-a-jwt", codes.Unauthenticated},
+		{"token of an unknown user", "Bearer " + unknown, codes.Unauthenticated},
+		{"valid bearer token", "Bearer " + token, codes.OK},
+		{"valid bare token", token, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+
+			_, err := client.GetCountry(ctx, &countrypb.GetCountryRequest{Lookup: &countrypb.GetCountryRequest_Code{Code: "NOR"}})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("GetCountry: got %v, want %v", got, tt.want)
+			}
+
+			_, err = client.FilterCountries(ctx, &countrypb.FilterCountriesRequest{Region: []string{"europe"}})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("FilterCountries: got %v, want %v", got, tt.want)
+			}
+
+			stream, err := client.ListCountries(ctx, &countrypb.ListCountriesRequest{})
+			if err == nil {
+				for err == nil {
+					_, err = stream.Recv()
+				}
+				if err == io.EOF {
+					err = nil
+				}
+			}
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("ListCountries: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGRPCWithoutSigningKey checks that RPCs signing or checking tokens fail with Unavailable
+// while no signing key is loaded.
+func TestGRPCWithoutSigningKey(t *testing.T) {
+	useCatalog(t, testCountries(t))
+	newTestUser(t, useUserStore(t), "bob")
+	token, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useSigningKey(t, nil)
+	client := countrypb.NewCountryServiceClient(dialTestGRPCServer(t))
+	ctx := context.Background()
+
+	_, err = client.Authenticate(ctx, &countrypb.AuthenticateRequest{Username: "bob", Password: testPassword})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Authenticate: got %v, want Unavailable", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	_, err = client.GetCountry(ctx, &countrypb.GetCountryRequest{Lookup: &countrypb.GetCountryRequest_Code{Code: "NOR"}})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("GetCountry: got %v, want Unavailable", err)
+	}
+}
+
+// TestGRPCPublicMethods checks that Authenticate and the reflection service work without a token.
+func TestGRPCPublicMethods(t *testing.T) {
+	newTestUser(t, useUserStore(t), "bob")
+	conn := dialTestGRPCServer(t)
+	ctx := context.Background()
+
+	client := countrypb.NewCountryServiceClient(conn)
+	resp, err := client.Authenticate(ctx, &countrypb.AuthenticateRequest{Username: "bob", Password: testPassword})
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if _, err := validateToken(resp.GetToken()); err != nil {
+		t.Errorf("Authenticate returned an invalid token: %v", err)
+	}
+	_, err = client.Authenticate(ctx, &countrypb.AuthenticateRequest{Username: "bob", Password: "wrong"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Authenticate with a wrong password: got %v, want Unauthenticated", err)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("reflection without a token: %v", err)
+	}
+
+	var services []string
+	for _, service := range reply.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	if !slices.Contains(services, "country.v1.CountryService") {
+		t.Errorf("reflection lists %v", services)
+	}
+}
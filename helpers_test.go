@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// TestMain signs tokens and pagination cursors with a random key, as the server does in
+// development when no secret is configured.
+func TestMain(m *testing.M) {
+	material, err := generateSigningMaterial(algHS256)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generating signing key: %v\n", err)
+		os.Exit(1)
+	}
+	key, err := newSigningKey(algHS256, material, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generating signing key: %v\n", err)
+		os.Exit(1)
+	}
+	tokenKeys = &SecretStore{key: key}
+	os.Exit(m.Run())
+}
+
+// countryCodes returns the cca3 codes of countries in order.
+func countryCodes(countries []Country) []string {
+	codes := make([]string, 0, len(countries))
+	for _, country := range countries {
+		codes = append(codes, country.CCA3)
+	}
+	return codes
+}
+
+// useCatalog serves countries from the global catalog for the rest of the test.
+func useCatalog(t *testing.T, countries []Country) {
+	t.Helper()
+
+	previous := catalog
+	catalog = NewCatalog(NewMemoryProvider(countries), 0)
+	t.Cleanup(func() { catalog = previous })
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("loading test catalog: %v", err)
+	}
+}
+
+// testCountries loads the embedded dataset, failing the test if it cannot be decoded.
+func testCountries(t *testing.T) []Country {
+	t.Helper()
+
+	countries, err := loadEmbeddedCountries()
+	if err != nil {
+		t.Fatalf("loading embedded countries: %v", err)
+	}
+	return countries
+}
+
+// getFilterPage calls the filter endpoint with rawQuery and decodes the page it returns.
+func getFilterPage(t *testing.T, rawQuery string) CountryFilterResponse {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?"+rawQuery, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("filter %q: status %d: %s", rawQuery, rec.Code, rec.Body)
+	}
+
+	var page CountryFilterResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decoding filter %q: %v", rawQuery, err)
+	}
+	return page
+}
+
+// runCommand runs the command line with args, feeding stdin to it, and returns what it printed.
+func runCommand(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	input := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(input, []byte(stdin), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	previous := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = previous }()
+
+	var out bytes.Buffer
+	app := &cli.App{
+		Name:      "country_assignment_api",
+		Writer:    &out,
+		ErrWriter: &out,
+		Commands:  []*cli.Command{serveCommand(), userCommand(), keyCommand()},
+	}
+	err = app.Run(append([]string{"country_assignment_api"}, args...))
+	return out.String(), err
+}
+
+// mustJSON marshals v, failing the test on error.
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// testPassword is the password of the users created by newTestUser.
+const testPassword = "Zebra-Quartz-917!"
+
+// newTestUser adds a user with testPassword to store.
+func newTestUser(t *testing.T, store UserStore, username string) User {
+	t.Helper()
+
+	user, err := newUser(username, testPassword)
+	if err != nil {
+		t.Fatalf("creating user %s: %v", username, err)
+	}
+	if err := store.Create(context.Background(), user); err != nil {
+		t.Fatalf("adding user %s: %v", username, err)
+	}
+	return user
+}
+
+// useUserStore checks logins and tokens against an empty file store for the rest of the test.
+func useUserStore(t *testing.T) UserStore {
+	t.Helper()
+
+	previous := users
+	users = NewFileUserStore(filepath.Join(t.TempDir(), "users.json"))
+	t.Cleanup(func() { users = previous })
+	return users
+}
+
+// newTestSigningKey generates a random algorithm signing key.
+func newTestSigningKey(t *testing.T, algorithm string) *signingKey {
+	t.Helper()
+
+	material, err := generateSigningMaterial(algorithm)
+	if err != nil {
+		t.Fatalf("generating %s key: %v", algorithm, err)
+	}
+	key, err := newSigningKey(algorithm, material, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// useSigningKey signs tokens and pagination cursors with key for the rest of the test.
+func useSigningKey(t *testing.T, key *signingKey) {
+	t.Helper()
+
+	previous := tokenKeys
+	tokenKeys = &SecretStore{key: key}
+	t.Cleanup(func() { tokenKeys = previous })
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// keyCommand manages the signing keys in KEYRING_FILE. A running server picks up the changes
+// within a second.
+func keyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "key",
+		Usage: "Manage the keyring of token signing keys (requires KEYRING_FILE)",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "rotate",
+				Usage: "Make a new key active; the previous one keeps verifying tokens until they expire",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "algorithm",
+						Usage: "algorithm of the new key: HS256, RS256, ES256 or EdDSA (default: JWT_ALGORITHM)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					keyring, err := openKeyring()
+					if err != nil {
+						return err
+					}
+
+					key, err := keyring.Rotate(c.String("algorithm"))
+					if err != nil {
+						return err
+					}
+					fmt.Fprintf(c.App.Writer, "Rotated to %s key %s\n", key.Algorithm, key.ID)
+					return nil
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the keys and their status",
+				Action: func(c *cli.Context) error {
+					keyring, err := openKeyring()
+					if err != nil {
+						return err
+					}
+
+					keys, err := keyring.List()
+					if err != nil {
+						return err
+					}
+
+					tw := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+					fmt.Fprintln(tw, "KID\tALGORITHM\tSTATUS\tCREATED\tVERIFIES UNTIL")
+					for _, key := range keys {
+						until := "-"
+						if key.NotAfter != nil && key.RetiredAt == nil {
+							until = key.NotAfter.Format(time.RFC3339)
+						}
+						fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", key.ID, key.Algorithm, key.Status, key.CreatedAt.Format(time.RFC3339), until)
+					}
+					return tw.Flush()
+				},
+			},
+			{
+				Name:      "retire",
+				Usage:     "Stop accepting tokens signed with a superseded key",
+				ArgsUsage: "<kid>",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return fmt.Errorf("expected exactly one key ID, got %d arguments", c.NArg())
+					}
+
+					keyring, err := openKeyring()
+					if err != nil {
+						return err
+					}
+
+					kid := c.Args().First()
+					if _, err := keyring.Retire(kid); err != nil {
+						if errors.Is(err, errKeyNotFound) {
+							return fmt.Errorf("no key with ID %s", kid)
+						}
+						return err
+					}
+					fmt.Fprintf(c.App.Writer, "Retired key %s\n", kid)
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// openKeyring opens the configured keyring, creating it with a first key if it does not exist.
+func openKeyring() (*Keyring, error) {
+	cfg, err := loadKeyringConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.KeyringFile == "" {
+		return nil, errors.New("the key commands need KEYRING_FILE to be set")
+	}
+
+	keyring := NewKeyring(cfg.KeyringFile, cfg.JWTAlgorithm)
+	if err := keyring.Load(); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
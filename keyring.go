@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keyringCheckInterval is how often a keyring checks its file for changes made by other processes.
+const keyringCheckInterval = time.Second
+
+// Key states reported by Keyring.List.
+const (
+	keyActive    = "active"
+	keyVerifying = "verifying"
+	keyExpired   = "expired"
+	keyRetired   = "retired"
+)
+
+var (
+	// errKeyNotFound is returned when no key in the keyring has the given id.
+	errKeyNotFound = errors.New("key not found")
+	// errRetireActiveKey is returned when retiring the key that new tokens are signed with.
+	errRetireActiveKey = errors.New("cannot retire the active key, rotate first")
+	// errNoSigningKey is returned when tokens or cursors are signed or checked before any key
+	// has been loaded.
+	errNoSigningKey = errors.New("no signing key is loaded")
+)
+
+// TokenKeys provides the keys that tokens and pagination cursors are signed and verified with.
+type TokenKeys interface {
+	// Key returns the key new tokens are signed with.
+	Key() *signingKey
+	// Lookup returns the key with the given id, if tokens signed with it are still accepted.
+	Lookup(id string) (*signingKey, bool)
+	// VerificationKeys returns every key that tokens are accepted from.
+	VerificationKeys() []*signingKey
+}
+
+// tokenKeys holds the keys of the running server: a SecretStore, or a Keyring when
+// KEYRING_FILE is set.
+var tokenKeys TokenKeys = &SecretStore{}
+
+// keyringEntry is a key as stored in the keyring file.
+type keyringEntry struct {
+	ID        string `json:"kid"`
+	Algorithm string `json:"algorithm"`
+	// Material is the HS256 secret or the PEM encoded private key.
+	Material  []byte    `json:"material"`
+	CreatedAt time.Time `json:"created_at"`
+	// SupersededAt is when a newer key became active. Tokens signed with this key keep being
+	// accepted until NotAfter, by which time every token it signed has expired.
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
+	NotAfter     *time.Time `json:"not_after,omitempty"`
+	// RetiredAt is when the key was retired. Tokens signed with it are no longer accepted.
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// KeyInfo describes a key in the keyring, without its material.
+type KeyInfo struct {
+	ID           string     `json:"kid"`
+	Algorithm    string     `json:"algorithm"`
+	Status       string     `json:"status" enums:"active,verifying,expired,retired"`
+	CreatedAt    time.Time  `json:"created_at"`
+	SupersededAt *time.Time `json:"superseded_at,omitempty"`
+	NotAfter     *time.Time `json:"not_after,omitempty"`
+	RetiredAt    *time.Time `json:"retired_at,omitempty"`
+}
+
+// status returns the state of the entry at now.
+func (e keyringEntry) status(now time.Time) string {
+	switch {
+	case e.RetiredAt != nil:
+		return keyRetired
+	case e.SupersededAt == nil:
+		return keyActive
+	case e.NotAfter != nil && !now.Before(*e.NotAfter):
+		return keyExpired
+	default:
+		return keyVerifying
+	}
+}
+
+// info describes the entry at now.
+func (e keyringEntry) info(now time.Time) KeyInfo {
+	return KeyInfo{
+		ID:           e.ID,
+		Algorithm:    e.Algorithm,
+		Status:       e.status(now),
+		CreatedAt:    e.CreatedAt,
+		SupersededAt: e.SupersededAt,
+		NotAfter:     e.NotAfter,
+		RetiredAt:    e.RetiredAt,
+	}
+}
+
+// Keyring keeps signing keys in a JSON file. Exactly one key is active and signs new tokens;
+// keys superseded by a rotation keep verifying tokens for tokenLifetime, so rotating never
+// invalidates outstanding tokens. The file is re-read when it changes on disk, so rotations made
+// by the key commands are picked up by a running server.
+type Keyring struct {
+	path      string
+	algorithm string
+
+	mu        sync.Mutex
+	entries   []keyringEntry
+	keys      map[string]*signingKey
+	modTime   time.Time
+	size      int64
+	lastCheck time.Time
+}
+
+// NewKeyring creates a keyring backed by the file at path, generating algorithm keys on rotation.
+func NewKeyring(path, algorithm string) *Keyring {
+	return &Keyring{path: path, algorithm: algorithm}
+}
+
+// Load reads the keyring file. A missing or empty keyring gets a first active key.
+func (k *Keyring) Load() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.load(true); err != nil {
+		return err
+	}
+	if k.active() == nil {
+		_, err := k.rotate(k.algorithm)
+		return err
+	}
+	return nil
+}
+
+// Key returns the active key.
+func (k *Keyring) Key() *signingKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.refresh()
+	if entry := k.active(); entry != nil {
+		return k.keys[entry.ID]
+	}
+	return nil
+}
+
+// Lookup returns the key with the given id if it is active or still verifying.
+func (k *Keyring) Lookup(id string) (*signingKey, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.refresh()
+	now := time.Now()
+	for _, entry := range k.entries {
+		if entry.ID == id && acceptsTokens(entry, now) {
+			return k.keys[entry.ID], true
+		}
+	}
+	return nil, false
+}
+
+// VerificationKeys returns the active and verifying keys, newest first.
+func (k *Keyring) VerificationKeys() []*signingKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.refresh()
+	now := time.Now()
+	var keys []*signingKey
+	for i := len(k.entries) - 1; i >= 0; i-- {
+		if acceptsTokens(k.entries[i], now) {
+			keys = append(keys, k.keys[k.entries[i].ID])
+		}
+	}
+	return keys
+}
+
+// List describes every key in the keyring, oldest first.
+func (k *Keyring) List() ([]KeyInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.load(false); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	list := make([]KeyInfo, 0, len(k.entries))
+	for _, entry := range k.entries {
+		list = append(list, entry.info(now))
+	}
+	return list, nil
+}
+
+// Rotate makes a new key of the given algorithm active, or of the keyring's algorithm if it is
+// empty. The previously active key keeps verifying tokens for tokenLifetime.
+func (k *Keyring) Rotate(algorithm string) (KeyInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.load(false); err != nil {
+		return KeyInfo{}, err
+	}
+	if algorithm == "" {
+		algorithm = k.algorithm
+	}
+	if !containsString(signingAlgorithms, algorithm) {
+		return KeyInfo{}, fmt.Errorf("invalid algorithm %q: must be one of %s", algorithm, strings.Join(signingAlgorithms, ", "))
+	}
+	return k.rotate(algorithm)
+}
+
+// Retire stops accepting tokens signed with the key, before its verification window ends.
+func (k *Keyring) Retire(id string) (KeyInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.load(false); err != nil {
+		return KeyInfo{}, err
+	}
+
+	now := time.Now().UTC()
+	for i := range k.entries {
+		entry := &k.entries[i]
+		if entry.ID != id {
+			continue
+		}
+		switch entry.status(now) {
+		case keyActive:
+			return KeyInfo{}, errRetireActiveKey
+		case keyRetired:
+			return entry.info(now), nil
+		}
+
+		entry.RetiredAt = &now
+		if err := k.save(); err != nil {
+			entry.RetiredAt = nil
+			return KeyInfo{}, err
+		}
+		return entry.info(now), nil
+	}
+	return KeyInfo{}, errKeyNotFound
+}
+
+// rotate generates a new active key and supersedes the current one. k.mu must be held.
+func (k *Keyring) rotate(algorithm string) (KeyInfo, error) {
+	material, err := generateSigningMaterial(algorithm)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+	key, err := newSigningKey(algorithm, material, true)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	now := time.Now().UTC()
+	notAfter := now.Add(tokenLifetime)
+	previous := append([]keyringEntry(nil), k.entries...)
+	for i := range k.entries {
+		if k.entries[i].status(now) == keyActive {
+			k.entries[i].SupersededAt = &now
+			k.entries[i].NotAfter = &notAfter
+		}
+	}
+
+	entry := keyringEntry{ID: key.id, Algorithm: algorithm, Material: material, CreatedAt: now}
+	k.entries = append(k.entries, entry)
+	if err := k.save(); err != nil {
+		k.entries = previous
+		return KeyInfo{}, err
+	}
+	return entry.info(now), nil
+}
+
+// active returns the active entry, or nil if there is none. k.mu must be held.
+func (k *Keyring) active() *keyringEntry {
+	now := time.Now()
+	for i := len(k.entries) - 1; i >= 0; i-- {
+		if k.entries[i].status(now) == keyActive {
+			return &k.entries[i]
+		}
+	}
+	return nil
+}
+
+// refresh reloads the file if it may have changed, keeping the current keys if that fails.
+// k.mu must be held.
+func (k *Keyring) refresh() {
+	if time.Since(k.lastCheck) < keyringCheckInterval {
+		return
+	}
+	if err := k.load(false); err != nil {
+		log.Printf("Reloading keyring %s failed, keeping the current keys: %s", k.path, err)
+	}
+}
+
+// load re-reads the file if it changed since it was last read, or unconditionally if force is
+// set. k.mu must be held.
+func (k *Keyring) load(force bool) error {
+	k.lastCheck = time.Now()
+
+	info, err := os.Stat(k.path)
+	if errors.Is(err, os.ErrNotExist) {
+		k.entries, k.keys = nil, make(map[string]*signingKey)
+		k.modTime, k.size = time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !force && k.keys != nil && info.ModTime().Equal(k.modTime) && info.Size() == k.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(k.path)
+	if err != nil {
+		return err
+	}
+
+	var file struct {
+		Keys []keyringEntry `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("decoding keyring %s: %w", k.path, err)
+	}
+
+	keys := make(map[string]*signingKey, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := newSigningKey(entry.Algorithm, entry.Material, true)
+		if err != nil {
+			return fmt.Errorf("key %s in keyring %s: %w", entry.ID, k.path, err)
+		}
+		if key.id != entry.ID {
+			return fmt.Errorf("key %s in keyring %s does not match its material", entry.ID, k.path)
+		}
+		keys[entry.ID] = key
+	}
+
+	k.entries, k.keys = file.Keys, keys
+	k.modTime, k.size = info.ModTime(), info.Size()
+	return nil
+}
+
+// save writes the keyring file, readable only by its owner. k.mu must be held.
+func (k *Keyring) save() error {
+	data, err := json.MarshalIndent(map[string]interface{}{"keys": k.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(k.path, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return k.load(true)
+}
+
+// acceptsTokens reports whether tokens signed with the entry's key are accepted at now.
+func acceptsTokens(entry keyringEntry, now time.Time) bool {
+	status := entry.status(now)
+	return status == keyActive || status == keyVerifying
+}
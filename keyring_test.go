@@ -0,0 +1,350 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// useKeyring makes tokens and cursors sign with a new keyring of algorithm keys for the rest of
+// the test.
+func useKeyring(t *testing.T, algorithm string) *Keyring {
+	t.Helper()
+
+	keyring := NewKeyring(filepath.Join(t.TempDir(), "keyring.json"), algorithm)
+	if err := keyring.Load(); err != nil {
+		t.Fatalf("loading keyring: %v", err)
+	}
+
+	previous := tokenKeys
+	tokenKeys = keyring
+	t.Cleanup(func() { tokenKeys = previous })
+	return keyring
+}
+
+// expireKey moves the end of a superseded key's verification window into the past, as if
+// tokenLifetime had passed since it was superseded.
+func expireKey(t *testing.T, keyring *Keyring, id string) {
+	t.Helper()
+
+	keyring.mu.Lock()
+	defer keyring.mu.Unlock()
+
+	past := time.Now().Add(-time.Second).UTC()
+	for i := range keyring.entries {
+		if keyring.entries[i].ID == id {
+			keyring.entries[i].NotAfter = &past
+		}
+	}
+	if err := keyring.save(); err != nil {
+		t.Fatalf("saving keyring: %v", err)
+	}
+}
+
+// keyStatus returns the status List reports for the key with the given id.
+func keyStatus(t *testing.T, keyring *Keyring, id string) string {
+	t.Helper()
+
+	keys, err := keyring.List()
+	if err != nil {
+		t.Fatalf("listing keys: %v", err)
+	}
+	for _, key := range keys {
+		if key.ID == id {
+			return key.Status
+		}
+	}
+	t.Fatalf("key %s is not listed", id)
+	return ""
+}
+
+// TestKeyringRotation checks that a superseded key keeps verifying tokens until its window ends
+// or it is retired, and that the active key cannot be retired.
+func TestKeyringRotation(t *testing.T) {
+	keyring := useKeyring(t, algEdDSA)
+
+	info, err := os.Stat(keyring.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keyring file has mode %o, want 600", perm)
+	}
+
+	first := keyring.Key()
+	oldToken, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := keyring.Rotate(algES256)
+	if err != nil {
+		t.Fatalf("rotating: %v", err)
+	}
+	if keyring.Key().id != rotated.ID || rotated.ID == first.id {
+		t.Fatalf("active key is %s after rotating to %s", keyring.Key().id, rotated.ID)
+	}
+	if rotated.Algorithm != algES256 || rotated.Status != keyActive {
+		t.Errorf("rotated key: got %+v", rotated)
+	}
+
+	newToken, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := validateToken(token); err != nil {
+			t.Errorf("%s token after rotating: %v", name, err)
+		}
+	}
+	if got := keyStatus(t, keyring, first.id); got != keyVerifying {
+		t.Errorf("superseded key is %s, want %s", got, keyVerifying)
+	}
+	if keys := keyring.VerificationKeys(); len(keys) != 2 || keys[0].id != rotated.ID {
+		t.Errorf("verification keys: got %d, want the new and the superseded key, newest first", len(keys))
+	}
+
+	if _, err := keyring.Retire(rotated.ID); !errors.Is(err, errRetireActiveKey) {
+		t.Errorf("retiring the active key: got %v, want errRetireActiveKey", err)
+	}
+	if _, err := keyring.Retire("no-such-key"); !errors.Is(err, errKeyNotFound) {
+		t.Errorf("retiring an unknown key: got %v, want errKeyNotFound", err)
+	}
+
+	// Once every token it signed has expired, the superseded key stops verifying
+	expireKey(t, keyring, first.id)
+	if _, ok := keyring.Lookup(first.id); ok {
+		t.Error("an expired key is still looked up")
+	}
+	if _, err := validateToken(oldToken); err == nil {
+		t.Error("a token signed with an expired key is still valid")
+	}
+	if got := keyStatus(t, keyring, first.id); got != keyExpired {
+		t.Errorf("expired key is %s, want %s", got, keyExpired)
+	}
+
+	// Retiring ends the verification window early
+	if _, err := keyring.Rotate(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validateToken(newToken); err != nil {
+		t.Fatalf("token of the superseded key before retiring: %v", err)
+	}
+	retired, err := keyring.Retire(rotated.ID)
+	if err != nil {
+		t.Fatalf("retiring: %v", err)
+	}
+	if retired.Status != keyRetired || retired.RetiredAt == nil {
+		t.Errorf("retired key: got %+v", retired)
+	}
+	if _, err := validateToken(newToken); err == nil {
+		t.Error("a token signed with a retired key is still valid")
+	}
+	if keyring.Key().method.Alg() != algEdDSA {
+		t.Errorf("rotating without an algorithm used %s, want the keyring's %s", keyring.Key().method.Alg(), algEdDSA)
+	}
+}
+
+// TestKeyringCursorSurvivesRotation checks that pagination cursors, like tokens, stay valid
+// while the key that signed them is verifying.
+func TestKeyringCursorSurvivesRotation(t *testing.T) {
+	keyring := useKeyring(t, algHS256)
+	useCatalog(t, testCountries(t))
+
+	first := getFilterPage(t, "region=europe&limit=3")
+	if first.NextCursor == "" {
+		t.Fatal("first page has no next cursor")
+	}
+	signedBy := keyring.Key().id
+
+	if _, err := keyring.Rotate(""); err != nil {
+		t.Fatal(err)
+	}
+	getFilterPage(t, "region=europe&limit=3&cursor="+first.NextCursor)
+
+	if _, err := keyring.Rotate(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keyring.Retire(signedBy); err != nil {
+		t.Fatal(err)
+	}
+	query, err := parseCountryFilterQuery(map[string][]string{"region": {"europe"}, "limit": {"3"}, "cursor": {first.NextCursor}})
+	if !errors.Is(err, errInvalidCursor) {
+		t.Errorf("cursor of a retired key: got %+v, %v; want errInvalidCursor", query.cursor, err)
+	}
+}
+
+// TestKeyringReloadsChangedFile checks that a running keyring picks up rotations and retirements
+// written to the file by another process, such as the key commands.
+func TestKeyringReloadsChangedFile(t *testing.T) {
+	server := useKeyring(t, algHS256)
+	oldToken, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldKey := server.Key().id
+
+	command := NewKeyring(server.path, algHS256)
+	if err := command.Load(); err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := command.Rotate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The server checks the file at most every keyringCheckInterval
+	time.Sleep(keyringCheckInterval)
+	if server.Key().id != rotated.ID {
+		t.Fatalf("the server signs with %s after the file was rotated to %s", server.Key().id, rotated.ID)
+	}
+	if _, err := validateToken(oldToken); err != nil {
+		t.Errorf("token of the superseded key after reloading: %v", err)
+	}
+
+	if _, err := command.Retire(oldKey); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(keyringCheckInterval)
+	if _, err := validateToken(oldToken); err == nil {
+		t.Error("token of a key retired by another process is still valid")
+	}
+}
+
+// TestKeyringRejectsMismatchedMaterial checks that a key whose id does not match its material
+// is refused rather than trusted.
+func TestKeyringRejectsMismatchedMaterial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	keyring := NewKeyring(path, algHS256)
+	if err := keyring.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	keyring.mu.Lock()
+	keyring.entries[0].ID = "forged"
+	err := keyring.save()
+	keyring.mu.Unlock()
+	if err == nil {
+		t.Fatal("saving a keyring with a mismatched key id succeeded")
+	}
+
+	if err := NewKeyring(path, algHS256).Load(); err == nil {
+		t.Error("loading a keyring with a mismatched key id succeeded")
+	}
+}
+
+// TestAdminKeyHandlers checks that the key endpoints need an admin user and a keyring, and
+// rotate and retire keys.
+func TestAdminKeyHandlers(t *testing.T) {
+	keyring := useKeyring(t, algHS256)
+	store := useUserStore(t)
+	newTestUser(t, store, "admin")
+	newTestUser(t, store, "bob")
+	previous := adminUsers
+	adminUsers = []string{"admin"}
+	t.Cleanup(func() { adminUsers = previous })
+
+	router := mux.NewRouter()
+	router.Handle("/api/v1/admin/keys", AuthMiddleware(AdminMiddleware(http.HandlerFunc(ListKeysHandler))))
+	router.Handle("/api/v1/admin/keys/rotate", AuthMiddleware(AdminMiddleware(http.HandlerFunc(RotateKeyHandler))))
+	router.Handle("/api/v1/admin/keys/{kid}/retire", AuthMiddleware(AdminMiddleware(http.HandlerFunc(RetireKeyHandler))))
+	call := func(username, method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		token, err := generateToken(username)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, r)
+		return rec
+	}
+
+	first := keyring.Key().id
+	tests := []struct {
+		username, method, path, body string
+		status                       int
+	}{
+		{"bob", http.MethodGet, "/api/v1/admin/keys", "", http.StatusForbidden},
+		{"bob", http.MethodPost, "/api/v1/admin/keys/rotate", "", http.StatusForbidden},
+		{"admin", http.MethodGet, "/api/v1/admin/keys", "", http.StatusOK},
+		{"admin", http.MethodPost, "/api/v1/admin/keys/rotate", `{"algorithm":"PS256"}`, http.StatusBadRequest},
+		{"admin", http.MethodPost, "/api/v1/admin/keys/rotate", `{`, http.StatusBadRequest},
+		{"admin", http.MethodPost, "/api/v1/admin/keys/rotate", "", http.StatusOK},
+		{"admin", http.MethodPost, "/api/v1/admin/keys/no-such-key/retire", "", http.StatusNotFound},
+		{"admin", http.MethodPost, "/api/v1/admin/keys/" + first + "/retire", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := call(tt.username, tt.method, tt.path, tt.body); rec.Code != tt.status {
+			t.Errorf("%s %s %s: status %d, want %d: %s", tt.username, tt.method, tt.path, rec.Code, tt.status, rec.Body)
+		}
+	}
+
+	active := keyring.Key().id
+	if rec := call("admin", http.MethodPost, "/api/v1/admin/keys/"+active+"/retire", ""); rec.Code != http.StatusConflict {
+		t.Errorf("retiring the active key: status %d, want 409", rec.Code)
+	}
+	if got := keyStatus(t, keyring, first); got != keyRetired {
+		t.Errorf("first key is %s, want %s", got, keyRetired)
+	}
+
+	// A server signing with a fixed secret has no keys to manage
+	useSigningKey(t, newTestSigningKey(t, algHS256))
+	if rec := call("admin", http.MethodGet, "/api/v1/admin/keys", ""); rec.Code != http.StatusConflict {
+		t.Errorf("without a keyring: status %d, want 409", rec.Code)
+	}
+}
+
+// TestKeyCommands checks rotating, listing and retiring keys through the key commands.
+func TestKeyCommands(t *testing.T) {
+	if _, err := runCommand(t, "", "key", "list"); err == nil || !strings.Contains(err.Error(), "KEYRING_FILE") {
+		t.Errorf("without KEYRING_FILE: got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	t.Setenv("KEYRING_FILE", path)
+	t.Setenv("JWT_ALGORITHM", algES256)
+	// Server settings the key commands do not use are not checked
+	t.Setenv("COUNTRY_SOURCE", "nowhere")
+
+	output, err := runCommand(t, "", "key", "rotate", "--algorithm", algEdDSA)
+	if err != nil || !strings.HasPrefix(output, "Rotated to EdDSA key ") {
+		t.Fatalf("rotate: got %q, %v", output, err)
+	}
+
+	keyring := NewKeyring(path, algES256)
+	if err := keyring.Load(); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := keyring.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0].Algorithm != algES256 || keys[1].Algorithm != algEdDSA {
+		t.Fatalf("keys after rotating: %+v", keys)
+	}
+
+	if output, err := runCommand(t, "", "key", "list"); err != nil || !strings.Contains(output, keys[0].ID+"  ES256") {
+		t.Errorf("list: got %q, %v", output, err)
+	}
+	if _, err := runCommand(t, "", "key", "retire", keys[1].ID); !errors.Is(err, errRetireActiveKey) {
+		t.Errorf("retiring the active key: got %v, want errRetireActiveKey", err)
+	}
+	if _, err := runCommand(t, "", "key", "retire", "no-such-key"); err == nil || !strings.Contains(err.Error(), "no key with ID") {
+		t.Errorf("retiring an unknown key: got %v", err)
+	}
+	if output, err := runCommand(t, "", "key", "retire", keys[0].ID); err != nil || !strings.Contains(output, "Retired key") {
+		t.Errorf("retire: got %q, %v", output, err)
+	}
+	if _, err := runCommand(t, "", "key", "retire"); err == nil {
+		t.Error("retire without a key ID was accepted")
+	}
+}
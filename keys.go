@@ -0,0 +1,268 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token signing algorithms supported by JWT_ALGORITHM.
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+	algES256 = "ES256"
+	algEdDSA = "EdDSA"
+)
+
+// signingAlgorithms are the supported algorithms, in the order they are listed in errors.
+var signingAlgorithms = []string{algHS256, algRS256, algES256, algEdDSA}
+
+// minRSAKeyBits is the smallest RSA modulus accepted for RS256.
+const minRSAKeyBits = 2048
+
+// signingKey is a key that tokens are signed and verified with.
+type signingKey struct {
+	// id is the kid header of tokens signed with the key. Asymmetric keys use their RFC 7638
+	// JWK thumbprint, so the same key always has the same id.
+	id     string
+	method jwt.SigningMethod
+	// private signs tokens: the secret for HS256, a crypto.Signer otherwise.
+	private interface{}
+	// public verifies tokens: the secret for HS256, a crypto.PublicKey otherwise.
+	public interface{}
+	// cursorSecret keys the HMAC that signs pagination cursors.
+	cursorSecret []byte
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKSet is a set of public keys in JSON Web Key Set format.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// newSigningKey builds a key for algorithm from signing material: the secret itself for HS256,
+// or a PEM encoded private key (PKCS#8, PKCS#1 or SEC 1) for the asymmetric algorithms. In
+// production, secrets shorter than minSecretLength are refused; elsewhere they are only logged.
+func newSigningKey(algorithm string, material []byte, production bool) (*signingKey, error) {
+	if algorithm == algHS256 {
+		if len(material) < minSecretLength {
+			if production {
+				return nil, fmt.Errorf("JWT secret is %d bytes, production requires at least %d", len(material), minSecretLength)
+			}
+			log.Printf("Warning: JWT secret is only %d bytes, use at least %d", len(material), minSecretLength)
+		}
+
+		// The id is derived from the secret without revealing it
+		sum := sha256.Sum256(append([]byte("kid:"), material...))
+		return &signingKey{
+			id:           base64.RawURLEncoding.EncodeToString(sum[:12]),
+			method:       jwt.SigningMethodHS256,
+			private:      material,
+			public:       material,
+			cursorSecret: material,
+		}, nil
+	}
+
+	block, _ := pem.Decode(material)
+	if block == nil {
+		return nil, fmt.Errorf("%s signing key is not PEM encoded", algorithm)
+	}
+
+	var private interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		private, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q: expected a private key", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s signing key: %w", algorithm, err)
+	}
+
+	return asymmetricSigningKey(algorithm, private, block.Bytes)
+}
+
+// asymmetricSigningKey checks that private suits algorithm and builds its signing key. der is
+// the encoded private key, from which the cursor secret is derived.
+func asymmetricSigningKey(algorithm string, private interface{}, der []byte) (*signingKey, error) {
+	key := &signingKey{private: private}
+
+	switch algorithm {
+	case algRS256:
+		rsaKey, ok := private.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("RS256 requires an RSA key, got %T", private)
+		}
+		if rsaKey.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key is %d bits, RS256 requires at least %d", rsaKey.N.BitLen(), minRSAKeyBits)
+		}
+		key.method, key.public = jwt.SigningMethodRS256, &rsaKey.PublicKey
+
+	case algES256:
+		ecKey, ok := private.(*ecdsa.PrivateKey)
+		if !ok || ecKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ES256 requires a P-256 ECDSA key, got %T", private)
+		}
+		key.method, key.public = jwt.SigningMethodES256, &ecKey.PublicKey
+
+	case algEdDSA:
+		edKey, ok := private.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("EdDSA requires an Ed25519 key, got %T", private)
+		}
+		key.method, key.public = jwt.SigningMethodEdDSA, edKey.Public()
+
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	thumbprint, err := key.jwk().thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	key.id = thumbprint
+
+	sum := sha256.Sum256(append([]byte("cursor:"), der...))
+	key.cursorSecret = sum[:]
+	return key, nil
+}
+
+// generateSigningMaterial generates random signing material for algorithm: a secret of
+// minSecretLength bytes for HS256, or a PEM encoded PKCS#8 private key otherwise.
+func generateSigningMaterial(algorithm string) ([]byte, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case algHS256:
+		secret := make([]byte, minSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	case algRS256:
+		private, err = rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	case algES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case algEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// asymmetric reports whether the key has a public half that can be published.
+func (k *signingKey) asymmetric() bool {
+	return k.method != jwt.SigningMethodHS256
+}
+
+// jwk returns the public half of an asymmetric key as a JWK.
+func (k *signingKey) jwk() JWK {
+	jwk := JWK{KeyID: k.id, Use: "sig", Algorithm: k.method.Alg()}
+
+	switch public := k.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(bigEndianExponent(public.E))
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = public.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+
+	return jwk
+}
+
+// thumbprint returns the RFC 7638 SHA-256 thumbprint of the key, computed over its required
+// members in lexicographic order.
+func (j JWK) thumbprint() (string, error) {
+	var members map[string]string
+	switch j.KeyType {
+	case "RSA":
+		members = map[string]string{"e": j.E, "kty": j.KeyType, "n": j.N}
+	case "EC":
+		members = map[string]string{"crv": j.Curve, "kty": j.KeyType, "x": j.X, "y": j.Y}
+	case "OKP":
+		members = map[string]string{"crv": j.Curve, "kty": j.KeyType, "x": j.X}
+	default:
+		return "", errors.New("cannot take the thumbprint of a symmetric key")
+	}
+
+	// encoding/json writes map keys in sorted order, without whitespace
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// bigEndianExponent encodes an RSA public exponent in the fewest big-endian bytes.
+func bigEndianExponent(e int) []byte {
+	var out []byte
+	for ; e > 0; e >>= 8 {
+		out = append([]byte{byte(e)}, out...)
+	}
+	return out
+}
+
+// JWKSHandler publishes the public keys that tokens can be verified with, so other services can
+// validate tokens without holding the signing secret. With HS256 there is nothing to publish and
+// the set is empty.
+func JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range tokenKeys.VerificationKeys() {
+		if key.asymmetric() {
+			set.Keys = append(set.Keys, key.jwk())
+		}
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeEncoded(w, formatJSON, http.StatusOK, set)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testPrivateKeys are one private key per asymmetric algorithm, generated once as RSA keys are slow.
+var testPrivateKeys = func() map[string]interface{} {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	return map[string]interface{}{algRS256: rsaKey, algES256: ecKey, algEdDSA: edKey}
+}()
+
+// pemEncode encodes der in a PEM block of the given type.
+func pemEncode(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+// pkcs8PEM encodes a private key as PKCS#8 PEM.
+func pkcs8PEM(t *testing.T, private interface{}) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pemEncode("PRIVATE KEY", der)
+}
+
+// TestNewSigningKey checks the accepted key encodings and the keys refused for each algorithm.
+func TestNewSigningKey(t *testing.T) {
+	rsaKey := testPrivateKeys[algRS256].(*rsa.PrivateKey)
+	ecKey := testPrivateKeys[algES256].(*ecdsa.PrivateKey)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := []struct {
+		algorithm string
+		material  []byte
+	}{
+		{algHS256, []byte(strings.Repeat("k", minSecretLength))},
+		{algRS256, pkcs8PEM(t, rsaKey)},
+		{algRS256, pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))},
+		{algES256, pkcs8PEM(t, ecKey)},
+		{algES256, pemEncode("EC PRIVATE KEY", ecDER)},
+		{algEdDSA, pkcs8PEM(t, testPrivateKeys[algEdDSA])},
+	}
+	for _, tt := range valid {
+		key, err := newSigningKey(tt.algorithm, tt.material, true)
+		if err != nil {
+			t.Errorf("%s: %v", tt.algorithm, err)
+			continue
+		}
+		if key.method.Alg() != tt.algorithm || key.id == "" || len(key.cursorSecret) == 0 {
+			t.Errorf("%s: got %+v", tt.algorithm, key)
+		}
+		if again, _ := newSigningKey(tt.algorithm, tt.material, true); again.id != key.id {
+			t.Errorf("%s: the same key got ids %s and %s", tt.algorithm, key.id, again.id)
+		}
+	}
+
+	invalid := []struct {
+		name      string
+		algorithm string
+		material  []byte
+	}{
+		{"short secret", algHS256, []byte("short")},
+		{"not PEM", algRS256, []byte("not a key")},
+		{"public key", algRS256, pemEncode("PUBLIC KEY", []byte("x"))},
+		{"garbled key", algRS256, pemEncode("PRIVATE KEY", []byte("x"))},
+		{"EC key for RS256", algRS256, pkcs8PEM(t, ecKey)},
+		{"RSA key for ES256", algES256, pkcs8PEM(t, rsaKey)},
+		{"P-384 key for ES256", algES256, pkcs8PEM(t, p384)},
+		{"RSA key for EdDSA", algEdDSA, pkcs8PEM(t, rsaKey)},
+		{"1024 bit RSA key", algRS256, pkcs8PEM(t, smallRSA)},
+		{"unknown algorithm", "PS256", pkcs8PEM(t, rsaKey)},
+	}
+	for _, tt := range invalid {
+		if _, err := newSigningKey(tt.algorithm, tt.material, true); err == nil {
+			t.Errorf("%s was accepted", tt.name)
+		}
+	}
+}
+
+// TestTokenRoundTrip checks that tokens signed with each algorithm validate, carry the key id
+// and stop validating under another key.
+func TestTokenRoundTrip(t *testing.T) {
+	for _, algorithm := range signingAlgorithms {
+		t.Run(algorithm, func(t *testing.T) {
+			var key *signingKey
+			if private, ok := testPrivateKeys[algorithm]; ok {
+				var err error
+				if key, err = newSigningKey(algorithm, pkcs8PEM(t, private), true); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				key = newTestSigningKey(t, algorithm)
+			}
+			useSigningKey(t, key)
+
+			tokenString, err := generateToken("bob")
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := validateToken(tokenString)
+			if err != nil {
+				t.Fatalf("validating: %v", err)
+			}
+			if token.Header["kid"] != key.id || token.Method.Alg() != algorithm {
+				t.Errorf("got header %v", token.Header)
+			}
+
+			useSigningKey(t, newTestSigningKey(t, algorithm))
+			if _, err := validateToken(tokenString); err == nil {
+				t.Error("a token signed with another key was accepted")
+			}
+		})
+	}
+}
+
+// TestValidateTokenRejects checks forged, expired and unsigned tokens.
+func TestValidateTokenRejects(t *testing.T) {
+	key, err := newSigningKey(algRS256, pkcs8PEM(t, testPrivateKeys[algRS256]), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useSigningKey(t, key)
+
+	sign := func(method jwt.SigningMethod, private interface{}, kid string, claims jwt.MapClaims) string {
+		t.Helper()
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(private)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	valid := jwt.MapClaims{"sub": "bob", "exp": time.Now().Add(time.Hour).Unix()}
+	publicDER, err := x509.MarshalPKIXPublicKey(key.public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"HS256 keyed with the public key", sign(jwt.SigningMethodHS256, pemEncode("PUBLIC KEY", publicDER), key.id, valid)},
+		{"alg none", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, key.id, valid)},
+		{"no kid", sign(key.method, key.private, "", valid)},
+		{"unknown kid", sign(key.method, key.private, "other", valid)},
+		{"expired", sign(key.method, key.private, key.id, jwt.MapClaims{"sub": "bob", "exp": time.Now().Add(-time.Minute).Unix()})},
+		{"no expiry", sign(key.method, key.private, key.id, jwt.MapClaims{"sub": "bob"})},
+	}
+	for _, tt := range tests {
+		if _, err := validateToken(tt.token); err == nil {
+			t.Errorf("%s was accepted", tt.name)
+		}
+	}
+	if _, err := validateToken(sign(key.method, key.private, key.id, valid)); err != nil {
+		t.Errorf("a valid token was refused: %v", err)
+	}
+}
+
+// TestJWKSHandler checks that asymmetric public keys are published and HS256 secrets are not.
+func TestJWKSHandler(t *testing.T) {
+	get := func() JWKSet {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		JWKSHandler(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+			t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+		}
+		var set JWKSet
+		if err := json.Unmarshal(rec.Body.Bytes(), &set); err != nil {
+			t.Fatal(err)
+		}
+		return set
+	}
+
+	useSigningKey(t, newTestSigningKey(t, algHS256))
+	if set := get(); len(set.Keys) != 0 {
+		t.Errorf("HS256 published %+v", set.Keys)
+	}
+
+	for _, algorithm := range []string{algRS256, algES256, algEdDSA} {
+		key, err := newSigningKey(algorithm, pkcs8PEM(t, testPrivateKeys[algorithm]), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		useSigningKey(t, key)
+
+		set := get()
+		if len(set.Keys) != 1 {
+			t.Fatalf("%s: published %+v", algorithm, set.Keys)
+		}
+		jwk := set.Keys[0]
+		if jwk.KeyID != key.id || jwk.Algorithm != algorithm || jwk.Use != "sig" {
+			t.Errorf("%s: published %+v", algorithm, jwk)
+		}
+		if thumbprint, err := jwk.thumbprint(); err != nil || thumbprint != key.id {
+			t.Errorf("%s: thumbprint %s, %v, want the key id %s", algorithm, thumbprint, err, key.id)
+		}
+	}
+}
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/urfave/cli/v2"
 
 	_ "country_assignment_api/docs"
 )
@@ -22,13 +25,111 @@ import (
 // @Schemes https
 
 func main() {
+	app := &cli.App{
+		Name:  "country_assignment_api",
+		Usage: "REST API serving country information",
+		Action: func(c *cli.Context) error {
+			return runServer()
+		},
+		Commands: []*cli.Command{
+			serveCommand(),
+			userCommand(),
+			keyCommand(),
+			snapshotCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runServer loads the country catalog and serves the HTTP and gRPC APIs until a listener fails.
+func runServer() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	provider, err := newCountryProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.KeyringFile != "" {
+		keyring := NewKeyring(cfg.KeyringFile, cfg.JWTAlgorithm)
+		if err := keyring.Load(); err != nil {
+			return err
+		}
+		tokenKeys = keyring
+	} else {
+		secrets := NewSecretStore(newSecretProvider(cfg), cfg.JWTAlgorithm, cfg.Production())
+		if err := secrets.Load(context.Background()); err != nil {
+			return err
+		}
+		go secrets.ReloadOnSignal(context.Background())
+		tokenKeys = secrets
+	}
+	adminUsers = cfg.AdminUsers
+
+	passwordHashAlgorithm = cfg.PasswordHash
+	users, err = newUserStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer users.Close()
+
+	if cfg.BootstrapUser != "" {
+		created, err := bootstrapUser(context.Background(), users, cfg.BootstrapUser, cfg.BootstrapPassword)
+		if err != nil {
+			return fmt.Errorf("creating bootstrap user: %w", err)
+		}
+		if created {
+			log.Printf("Created bootstrap user %s", cfg.BootstrapUser)
+		}
+	}
+	if existing, err := users.List(context.Background()); err == nil && len(existing) == 0 {
+		log.Printf("Warning: the user store at %s has no users, nobody can log in", cfg.UserStorePath)
+	}
+
+	if cfg.CountrySource == sourceEmbedded {
+		log.Printf("Warning: serving the embedded sample dataset, which only holds some of the countries")
+	}
+	catalog = NewCatalog(provider, cfg.RefreshInterval)
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		log.Printf("Initial country catalog load failed, retrying in the background: %s", err)
+	} else {
+		log.Printf("Country catalog loaded: %d countries", catalog.Status().Countries)
+	}
+	go catalog.Run(context.Background())
+
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return err
+	}
+	go func() {
+		log.Printf("gRPC server started on :%s\n", cfg.GRPCPort)
+		if err := newGRPCServer().Serve(grpcListener); err != nil {
+			log.Fatalf("gRPC server failed: %s", err)
+		}
+	}()
+
 	r := mux.NewRouter()
 
 	r.HandleFunc("/", welcomeHandler)
+	r.HandleFunc("/.well-known/jwks.json", JWKSHandler).Methods("GET")
 	r.HandleFunc("/api/v1/auth", AuthHandler).Methods("POST")
 	r.Handle("/api/v1/country", AuthMiddleware(http.HandlerFunc(CountryDetailsHandler))).Methods("GET")
+	r.Handle("/api/v1/country/{code}", AuthMiddleware(http.HandlerFunc(CountryByCodeHandler))).Methods("GET")
 	r.Handle("/api/v1/countries", AuthMiddleware(http.HandlerFunc(CountriesListHandler))).Methods("GET")
 	r.Handle("/api/v1/countries/filter", AuthMiddleware(http.HandlerFunc(CountriesFilterListHandler))).Methods("GET")
+	r.Handle("/api/v1/countries/export", AuthMiddleware(http.HandlerFunc(CountriesExportHandler))).Methods("GET")
+	r.Handle("/api/v1/graphql", AuthMiddleware(http.HandlerFunc(GraphQLHandler))).Methods("GET", "POST")
+	r.HandleFunc("/api/v1/catalog/status", CatalogStatusHandler).Methods("GET")
+	r.Handle("/api/v1/admin/keys", AdminMiddleware(http.HandlerFunc(ListKeysHandler))).Methods("GET")
+	r.Handle("/api/v1/admin/keys/rotate", AdminMiddleware(http.HandlerFunc(RotateKeyHandler))).Methods("POST")
+	r.Handle("/api/v1/admin/keys/{kid}/retire", AdminMiddleware(http.HandlerFunc(RetireKeyHandler))).Methods("POST")
 
 	// Swagger documentation
 	r.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
@@ -45,13 +146,8 @@ func main() {
 	// Create a new HTTP handler with the CORS middleware
 	corsRouter := corsHandler(r)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-
-	log.Printf("Server started on :%s\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, corsRouter))
+	log.Printf("Server started on :%s\n", cfg.Port)
+	return http.ListenAndServe(":"+cfg.Port, corsRouter)
 }
 
 func welcomeHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Name match modes accepted by the country details endpoint.
+const (
+	matchExact     = "exact"
+	matchOfficial  = "official"
+	matchPrefix    = "prefix"
+	matchSubstring = "substring"
+	matchFuzzy     = "fuzzy"
+)
+
+// fuzzyMinScore is the lowest similarity a fuzzy match needs to be returned.
+const fuzzyMinScore = 0.6
+
+// CountryMatch is a country matched by name, with a score between 0 and 1 where 1 is an exact match.
+type CountryMatch struct {
+	Country
+	Score float64 `json:"score"`
+}
+
+// matchCountries returns the countries matching query in the given mode, best match first.
+func matchCountries(countries []Country, query, mode string) ([]CountryMatch, error) {
+	var score func(country Country, query string) float64
+
+	switch mode {
+	case matchExact:
+		score = scoreExact
+	case matchOfficial:
+		score = scoreOfficial
+	case matchPrefix:
+		score = scorePrefix
+	case "", matchSubstring:
+		score = scoreSubstring
+	case matchFuzzy:
+		score = scoreFuzzy
+	default:
+		return nil, fmt.Errorf("invalid match mode %q: must be one of %s, %s, %s, %s or %s", mode, matchExact, matchOfficial, matchPrefix, matchSubstring, matchFuzzy)
+	}
+
+	query = normalizeName(query)
+
+	matches := []CountryMatch{}
+	for _, country := range countries {
+		if s := score(country, query); s > 0 {
+			matches = append(matches, CountryMatch{Country: country, Score: roundScore(s)})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return strings.ToLower(matches[i].Name.Common) < strings.ToLower(matches[j].Name.Common)
+	})
+
+	return matches, nil
+}
+
+// scoreExact matches the common name exactly.
+func scoreExact(country Country, query string) float64 {
+	if normalizeName(country.Name.Common) == query {
+		return 1
+	}
+	return 0
+}
+
+// scoreOfficial matches the official name exactly.
+func scoreOfficial(country Country, query string) float64 {
+	if normalizeName(country.Name.Official) == query {
+		return 1
+	}
+	return 0
+}
+
+// scorePrefix matches common or official names starting with query; shorter names score higher.
+func scorePrefix(country Country, query string) float64 {
+	best := 0.0
+	for _, name := range []string{country.Name.Common, country.Name.Official} {
+		name = normalizeName(name)
+		if strings.HasPrefix(name, query) {
+			best = maxScore(best, coverage(query, name))
+		}
+	}
+	return best
+}
+
+// scoreSubstring matches common or official names containing query; names starting with it rank higher.
+func scoreSubstring(country Country, query string) float64 {
+	best := 0.0
+	for _, name := range []string{country.Name.Common, country.Name.Official} {
+		name = normalizeName(name)
+		switch {
+		case strings.HasPrefix(name, query):
+			best = maxScore(best, coverage(query, name))
+		case strings.Contains(name, query):
+			best = maxScore(best, 0.9*coverage(query, name))
+		}
+	}
+	return best
+}
+
+// scoreFuzzy scores query by edit distance against every known name of the country:
+// common, official, native names, translations and alternative spellings.
+func scoreFuzzy(country Country, query string) float64 {
+	best := 0.0
+	for _, name := range countryNames(country) {
+		best = maxScore(best, similarity(query, normalizeName(name)))
+	}
+
+	if best < fuzzyMinScore {
+		return 0
+	}
+	return best
+}
+
+// countryNames returns every name a country is known by.
+func countryNames(country Country) []string {
+	names := []string{country.Name.Common, country.Name.Official}
+	for _, native := range country.Name.NativeName {
+		names = append(names, native.Common, native.Official)
+	}
+	for _, translation := range country.Translations {
+		names = append(names, translation.Common, translation.Official)
+	}
+	names = append(names, country.AltSpellings...)
+	return names
+}
+
+// similarity returns 1 minus the Levenshtein distance between a and b relative to the longer string.
+func similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// coverage returns the share of name covered by query.
+func coverage(query, name string) float64 {
+	if name == "" {
+		return 0
+	}
+	return float64(len([]rune(query))) / float64(len([]rune(name)))
+}
+
+// normalizeName lower-cases s, strips diacritics and collapses whitespace so "Côte d'Ivoire" matches "cote d'ivoire".
+func normalizeName(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, s)
+	if err != nil {
+		stripped = s
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(stripped)), " ")
+}
+
+// roundScore rounds s to three decimals to keep responses readable.
+func roundScore(s float64) float64 {
+	return float64(int(s*1000+0.5)) / 1000
+}
+
+func maxScore(a, b float64) float64 {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// matchTestCountries is a small dataset with the names the match tests look for.
+var matchTestCountries = []Country{
+	{Name: CountryName{Common: "India", Official: "Republic of India"}, CCA3: "IND"},
+	{Name: CountryName{Common: "Indonesia", Official: "Republic of Indonesia"}, CCA3: "IDN"},
+	{Name: CountryName{Common: "Iceland", Official: "Iceland"}, CCA3: "ISL"},
+	{Name: CountryName{Common: "Finland", Official: "Republic of Finland"}, CCA3: "FIN"},
+	{
+		Name:         CountryName{Common: "Germany", Official: "Federal Republic of Germany", NativeName: map[string]NativeName{"deu": {Common: "Deutschland", Official: "Bundesrepublik Deutschland"}}},
+		CCA3:         "DEU",
+		Translations: map[string]NativeName{"fra": {Common: "Allemagne", Official: "République fédérale d'Allemagne"}},
+	},
+	{Name: CountryName{Common: "Ivory Coast", Official: "Republic of Côte d'Ivoire"}, CCA3: "CIV", AltSpellings: StringList{"CI", "Côte d'Ivoire"}},
+}
+
+// TestMatchCountries checks every match mode, the ranking of matches and tie-breaking by name.
+func TestMatchCountries(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		mode  string
+		want  []string
+	}{
+		{"exact common name", "INDIA", matchExact, []string{"IND"}},
+		{"exact ignores official name", "Republic of India", matchExact, nil},
+		{"official name", "republic of  india", matchOfficial, []string{"IND"}},
+		{"official ignores common name", "India", matchOfficial, nil},
+		{"prefix ranks shorter names first", "ind", matchPrefix, []string{"IND", "IDN"}},
+		{"prefix needs the start", "land", matchPrefix, nil},
+		{"substring is the default", "donesia", "", []string{"IDN"}},
+		{"substring ties sort by name", "land", matchSubstring, []string{"FIN", "ISL"}},
+		{"substring ranks by coverage", "republic", matchSubstring, []string{"IND", "FIN", "IDN", "CIV", "DEU"}},
+		{"fuzzy typo", "germny", matchFuzzy, []string{"DEU"}},
+		{"fuzzy native name", "deutschlnd", matchFuzzy, []string{"DEU"}},
+		{"fuzzy translation", "allemagne", matchFuzzy, []string{"DEU"}},
+		{"fuzzy alt spelling without accents", "cote divoire", matchFuzzy, []string{"CIV"}},
+		{"fuzzy below threshold", "xyzzy", matchFuzzy, nil},
+		{"empty query", "", matchSubstring, nil},
+		{"empty fuzzy query", "", matchFuzzy, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := matchCountries(matchTestCountries, tt.query, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for i, match := range matches {
+				got = append(got, match.CCA3)
+				if match.Score <= 0 || match.Score > 1 {
+					t.Errorf("%s: score %v out of range", match.CCA3, match.Score)
+				}
+				if i > 0 && match.Score > matches[i-1].Score {
+					t.Errorf("%s ranks below a lower score", match.CCA3)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchCountriesScores checks that exact matches score 1 and partial matches score by coverage.
+func TestMatchCountriesScores(t *testing.T) {
+	tests := []struct {
+		query string
+		mode  string
+		want  float64
+	}{
+		{"india", matchExact, 1},
+		{"ind", matchPrefix, 0.6},
+		{"dia", matchSubstring, 0.54},
+		{"indai", matchFuzzy, 0.6},
+	}
+	for _, tt := range tests {
+		matches, err := matchCountries(matchTestCountries[:1], tt.query, tt.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 1 || matches[0].Score != tt.want {
+			t.Errorf("%s %q: got %+v, want score %v", tt.mode, tt.query, matches, tt.want)
+		}
+	}
+}
+
+// TestMatchCountriesRejectsUnknownMode checks that an unknown match mode is an error.
+func TestMatchCountriesRejectsUnknownMode(t *testing.T) {
+	for _, mode := range []string{"regex", "EXACT", " "} {
+		if _, err := matchCountries(matchTestCountries, "india", mode); err == nil {
+			t.Errorf("mode %q was accepted", mode)
+		}
+	}
+}
+
+// TestNormalizeName checks case folding, diacritic stripping and whitespace collapsing.
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"India", "india"},
+		{"  Côte   D'Ivoire ", "cote d'ivoire"},
+		{"São Tomé and Príncipe", "sao tome and principe"},
+		{"Åland\tIslands", "aland islands"},
+		{"Türkiye", "turkiye"},
+	}
+	for _, tt := range tests {
+		if got := normalizeName(tt.in); got != tt.want {
+			t.Errorf("normalizeName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestLevenshtein checks the edit distance on insertions, deletions, substitutions and runes.
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"germany", "germany", 0},
+		{"germny", "germany", 1},
+		{"germany", "germani", 1},
+		{"kitten", "sitting", 3},
+		{"åland", "aland", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
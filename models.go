@@ -10,3 +10,32 @@ type Credentials struct {
 type ErrorResponse struct {
 	Error string `json:"error" swaggerignore:"true"`
 }
+
+// CountryListResponse is the response body of the countries list endpoint.
+type CountryListResponse struct {
+	Country []Country `json:"country"`
+}
+
+// CountryDetailsResponse is the response body of the country details endpoint.
+type CountryDetailsResponse struct {
+	CountryData []Country `json:"country_data"`
+}
+
+// CountryMatchesResponse is the response body of a country lookup by name.
+type CountryMatchesResponse struct {
+	CountryData []CountryMatch `json:"country_data"`
+}
+
+// CountryFilterResponse is the paginated response body of the countries filter endpoint.
+// Country holds common names, or objects with the requested fields when fields is given.
+// Page is omitted when the page was requested with a cursor.
+type CountryFilterResponse struct {
+	Country    []interface{} `json:"country" swaggertype:"array,string"`
+	Total      int           `json:"total"`
+	Page       int           `json:"page,omitempty"`
+	PageSize   int           `json:"page_size"`
+	TotalPages int           `json:"total_pages"`
+	Next       string        `json:"next,omitempty"`
+	Prev       string        `json:"prev,omitempty"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultPageSize is the number of countries per page when no limit is given.
+	defaultPageSize = 20
+	// maxPageSize is the largest limit a client may request.
+	maxPageSize = 100
+)
+
+// pageRequest is the page and page size requested through the page and limit parameters.
+type pageRequest struct {
+	Page int
+	Size int
+}
+
+// parsePageRequest reads the page and limit query parameters, applying defaults.
+func parsePageRequest(query url.Values) (pageRequest, error) {
+	req := pageRequest{Page: 1, Size: defaultPageSize}
+
+	if s := query.Get("page"); s != "" {
+		page, err := strconv.Atoi(s)
+		if err != nil || page <= 0 {
+			return pageRequest{}, fmt.Errorf("Invalid page number")
+		}
+		req.Page = page
+	}
+
+	if s := query.Get("limit"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil || size <= 0 || size > maxPageSize {
+			return pageRequest{}, fmt.Errorf("Invalid limit: must be an integer between 1 and %d", maxPageSize)
+		}
+		req.Size = size
+	}
+
+	return req, nil
+}
+
+// pageInfo describes one page of a result set of total items.
+type pageInfo struct {
+	pageRequest
+	Total      int
+	TotalPages int
+}
+
+// newPageInfo computes the page boundaries for total items.
+func newPageInfo(req pageRequest, total int) pageInfo {
+	return pageInfo{
+		pageRequest: req,
+		Total:       total,
+		TotalPages:  (total + req.Size - 1) / req.Size,
+	}
+}
+
+// bounds returns the slice indexes of the page, clamped to the result set.
+func (p pageInfo) bounds() (start, end int) {
+	start = (p.Page - 1) * p.Size
+	if start > p.Total {
+		start = p.Total
+	}
+
+	end = start + p.Size
+	if end > p.Total {
+		end = p.Total
+	}
+
+	return start, end
+}
+
+// links returns the URLs of the first, previous, next and last pages relative to the request,
+// keyed by RFC 8288 relation type. Relations that do not apply are omitted.
+func (p pageInfo) links(r *http.Request) map[string]string {
+	links := make(map[string]string)
+	if p.TotalPages == 0 {
+		return links
+	}
+
+	links["first"] = pageURL(r, 1, p.Size)
+	links["last"] = pageURL(r, p.TotalPages, p.Size)
+	if p.Page < p.TotalPages {
+		links["next"] = pageURL(r, p.Page+1, p.Size)
+	}
+	if p.Page > 1 {
+		prev := p.Page - 1
+		if prev > p.TotalPages {
+			prev = p.TotalPages
+		}
+		links["prev"] = pageURL(r, prev, p.Size)
+	}
+
+	return links
+}
+
+// pageURL returns the request URL with its page and limit parameters replaced and any cursor dropped.
+func pageURL(r *http.Request, page, size int) string {
+	query := r.URL.Query()
+	query.Del("cursor")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(size))
+
+	u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return u.String()
+}
+
+// cursorURL returns the request URL continuing from cursor, without a page parameter.
+func cursorURL(r *http.Request, cursor string, size int) string {
+	query := r.URL.Query()
+	query.Del("page")
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(size))
+
+	u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+	return u.String()
+}
+
+// writeLinkHeader sets an RFC 8288 Link header for the given relation → URL map.
+func writeLinkHeader(w http.ResponseWriter, links map[string]string) {
+	var parts []string
+	for _, rel := range []string{"first", "prev", "next", "last"} {
+		if link, ok := links[rel]; ok {
+			parts = append(parts, fmt.Sprintf(`<%s>; rel="%s"`, link, rel))
+		}
+	}
+
+	if len(parts) > 0 {
+		w.Header().Set("Link", strings.Join(parts, ", "))
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestParsePageRequest checks the defaults and bounds of the page and limit parameters.
+func TestParsePageRequest(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    pageRequest
+		wantErr bool
+	}{
+		{"", pageRequest{Page: 1, Size: defaultPageSize}, false},
+		{"page=3", pageRequest{Page: 3, Size: defaultPageSize}, false},
+		{"limit=1", pageRequest{Page: 1, Size: 1}, false},
+		{"page=2&limit=100", pageRequest{Page: 2, Size: 100}, false},
+		{"page=0", pageRequest{}, true},
+		{"page=-1", pageRequest{}, true},
+		{"page=two", pageRequest{}, true},
+		{"limit=0", pageRequest{}, true},
+		{"limit=-5", pageRequest{}, true},
+		{"limit=101", pageRequest{}, true},
+		{"limit=2.5", pageRequest{}, true},
+	}
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		got, err := parsePageRequest(values)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestPageInfo checks page bounds and links on the first, middle, last and out-of-range pages.
+func TestPageInfo(t *testing.T) {
+	tests := []struct {
+		page, size, total int
+		start, end        int
+		totalPages        int
+		links             map[string]string
+	}{
+		{1, 10, 0, 0, 0, 0, map[string]string{}},
+		{1, 10, 25, 0, 10, 3, map[string]string{"first": "1", "next": "2", "last": "3"}},
+		{2, 10, 25, 10, 20, 3, map[string]string{"first": "1", "prev": "1", "next": "3", "last": "3"}},
+		{3, 10, 25, 20, 25, 3, map[string]string{"first": "1", "prev": "2", "last": "3"}},
+		{7, 10, 25, 25, 25, 3, map[string]string{"first": "1", "prev": "3", "last": "3"}},
+		{1, 5, 5, 0, 5, 1, map[string]string{"first": "1", "last": "1"}},
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("page %d of %d by %d", tt.page, tt.total, tt.size)
+		p := newPageInfo(pageRequest{Page: tt.page, Size: tt.size}, tt.total)
+
+		if start, end := p.bounds(); start != tt.start || end != tt.end {
+			t.Errorf("%s: bounds %d:%d, want %d:%d", name, start, end, tt.start, tt.end)
+		}
+		if p.TotalPages != tt.totalPages {
+			t.Errorf("%s: %d total pages, want %d", name, p.TotalPages, tt.totalPages)
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?region=europe&page=9", nil)
+		links := p.links(r)
+		if len(links) != len(tt.links) {
+			t.Errorf("%s: links %v, want pages %v", name, links, tt.links)
+		}
+		for rel, page := range tt.links {
+			want := fmt.Sprintf("/api/v1/countries/filter?limit=%d&page=%s&region=europe", tt.size, page)
+			if links[rel] != want {
+				t.Errorf("%s: %s link %q, want %q", name, rel, links[rel], want)
+			}
+		}
+	}
+}
+
+// TestWriteLinkHeader checks the RFC 8288 formatting and relation order of the Link header.
+func TestWriteLinkHeader(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeLinkHeader(rec, map[string]string{"last": "/l", "next": "/n", "first": "/f", "prev": "/p", "self": "/s"})
+
+	want := `</f>; rel="first", </p>; rel="prev", </n>; rel="next", </l>; rel="last"`
+	if got := rec.Header().Get("Link"); got != want {
+		t.Errorf("Link %q, want %q", got, want)
+	}
+
+	rec = httptest.NewRecorder()
+	writeLinkHeader(rec, map[string]string{})
+	if _, ok := rec.Header()["Link"]; ok {
+		t.Error("Link header set without links")
+	}
+}
+
+// TestCountriesFilterPagination checks the pagination metadata and Link header of the filter endpoint.
+func TestCountriesFilterPagination(t *testing.T) {
+	var countries []Country
+	for i := 0; i < 45; i++ {
+		countries = append(countries, Country{Name: CountryName{Common: fmt.Sprintf("Country %02d", i)}, CCA3: fmt.Sprintf("C%02d", i)})
+	}
+	useCatalog(t, countries)
+
+	tests := []struct {
+		query  string
+		status int
+		want   CountryFilterResponse
+		link   bool
+	}{
+		{"", http.StatusOK, CountryFilterResponse{Total: 45, Page: 1, PageSize: 20, TotalPages: 3, Next: "/api/v1/countries/filter?limit=20&page=2"}, true},
+		{"limit=40&page=2", http.StatusOK, CountryFilterResponse{Total: 45, Page: 2, PageSize: 40, TotalPages: 2, Prev: "/api/v1/countries/filter?limit=40&page=1"}, true},
+		{"population_min=1", http.StatusOK, CountryFilterResponse{Total: 0, Page: 1, PageSize: 20, TotalPages: 0}, false},
+		{"limit=-1", http.StatusBadRequest, CountryFilterResponse{}, false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		CountriesFilterListHandler(rec, httptest.NewRequest(http.MethodGet, "/api/v1/countries/filter?"+tt.query, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%q: status %d, want %d: %s", tt.query, rec.Code, tt.status, rec.Body)
+			continue
+		}
+		if (rec.Header().Get("Link") != "") != tt.link {
+			t.Errorf("%q: Link header %q", tt.query, rec.Header().Get("Link"))
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+
+		var got CountryFilterResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		start, end := newPageInfo(pageRequest{Page: got.Page, Size: got.PageSize}, got.Total).bounds()
+		if len(got.Country) != end-start {
+			t.Errorf("%q: %d countries on the page, want %d", tt.query, len(got.Country), end-start)
+		}
+		if (got.NextCursor != "") != (got.Next != "") {
+			t.Errorf("%q: next cursor %q with next link %q", tt.query, got.NextCursor, got.Next)
+		}
+		got.Country, got.NextCursor = nil, ""
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hash algorithms supported by PASSWORD_HASH.
+const (
+	hashArgon2id = "argon2id"
+	hashBcrypt   = "bcrypt"
+)
+
+// argon2id parameters for new hashes, following the second recommendation of RFC 9106.
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+)
+
+// bcryptCost is the cost of new bcrypt hashes.
+const bcryptCost = 12
+
+// minPasswordLength is the shortest password accepted for new or reset passwords.
+const minPasswordLength = 12
+
+// passphraseLength is the length from which a password needs no mix of character classes.
+const passphraseLength = 20
+
+// commonPasswords are well-known passwords refused regardless of their length or mix.
+var commonPasswords = map[string]bool{
+	"123456789012": true, "1234567890123": true, "password1234": true, "password12345": true,
+	"passwordpassword": true, "qwertyuiop12": true, "qwertyuiopasdf": true, "1q2w3e4r5t6y": true,
+	"iloveyou1234": true, "letmein12345": true, "administrator": true, "changeme1234": true,
+	"welcome12345": true, "trustno1trustno1": true, "abc123abc123": true, "qwerty123456": true,
+}
+
+// errUnknownHash is returned when a stored hash is in no supported format.
+var errUnknownHash = errors.New("unsupported password hash format")
+
+// passwordHashAlgorithm is the algorithm used for new password hashes.
+var passwordHashAlgorithm = hashArgon2id
+
+// hashPassword hashes password with passwordHashAlgorithm. argon2id hashes use the PHC string
+// format, e.g. $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>; bcrypt hashes the usual $2a$ format.
+func hashPassword(password string) (string, error) {
+	if passwordHashAlgorithm == hashBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verifyPassword reports whether password matches an argon2id or bcrypt hash. Keys are compared
+// in constant time.
+func verifyPassword(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return verifyArgon2id(hash, password)
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return false, errUnknownHash
+	}
+}
+
+// verifyArgon2id checks password against a PHC formatted argon2id hash.
+func verifyArgon2id(hash, password string) (bool, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=4", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false, errUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, errUnknownHash
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, errUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, errUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, errUnknownHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+// checkPasswordStrength refuses weak passwords. A password must have at least minPasswordLength
+// characters, must not contain the username or be a well-known password, and must mix at least
+// three of lowercase letters, uppercase letters, digits and symbols unless it is a passphrase of
+// passphraseLength characters or more.
+func checkPasswordStrength(username, password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < minPasswordLength {
+		return fmt.Errorf("password is too short: use at least %d characters", minPasswordLength)
+	}
+
+	lower := strings.ToLower(password)
+	if strings.Contains(lower, strings.ToLower(username)) {
+		return errors.New("password must not contain the username")
+	}
+	if commonPasswords[lower] {
+		return errors.New("password is too common")
+	}
+
+	distinct := make(map[rune]bool)
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		distinct[r] = true
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	if len(distinct) < 5 {
+		return errors.New("password repeats too few characters: use at least 5 different ones")
+	}
+
+	classes := 0
+	for _, has := range []bool{hasLower, hasUpper, hasDigit, hasSymbol} {
+		if has {
+			classes++
+		}
+	}
+	if classes < 3 && length < passphraseLength {
+		return fmt.Errorf("password is too simple: mix at least three of lowercase, uppercase, digits and symbols, or use %d or more characters", passphraseLength)
+	}
+
+	return nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// burnPasswordCheck verifies password against a throwaway hash, so that logins for unknown users
+// take as long as those for real ones.
+func burnPasswordCheck(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = hashPassword("not a real password")
+	})
+	verifyPassword(dummyHash, password)
+}
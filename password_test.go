@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPasswordHashRoundTrip checks that passwords verify against their own argon2id and bcrypt
+// hashes and nothing else.
+func TestPasswordHashRoundTrip(t *testing.T) {
+	previous := passwordHashAlgorithm
+	t.Cleanup(func() { passwordHashAlgorithm = previous })
+
+	for _, tt := range []struct{ algorithm, prefix string }{
+		{hashArgon2id, "$argon2id$v=19$m=65536,t=3,p=4$"},
+		{hashBcrypt, "$2a$12$"},
+	} {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			passwordHashAlgorithm = tt.algorithm
+
+			hash, err := hashPassword("Correct-Horse-42")
+			if err != nil {
+				t.Fatalf("hashing: %v", err)
+			}
+			if !strings.HasPrefix(hash, tt.prefix) {
+				t.Errorf("hash %q does not start with %q", hash, tt.prefix)
+			}
+
+			for password, want := range map[string]bool{
+				"Correct-Horse-42": true,
+				"correct-horse-42": false,
+				"Correct-Horse-4":  false,
+				"":                 false,
+			} {
+				ok, err := verifyPassword(hash, password)
+				if err != nil {
+					t.Fatalf("verifying %q: %v", password, err)
+				}
+				if ok != want {
+					t.Errorf("verifying %q: got %v, want %v", password, ok, want)
+				}
+			}
+
+			again, err := hashPassword("Correct-Horse-42")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again == hash {
+				t.Error("hashing the same password twice gave the same hash, the salt is not random")
+			}
+		})
+	}
+}
+
+// TestVerifyPasswordRejectsMalformedHashes checks that hashes in no supported format are errors
+// rather than mismatches or matches.
+func TestVerifyPasswordRejectsMalformedHashes(t *testing.T) {
+	const salt, key = "c29tZXNhbHRzb21lc2FsdA", "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	for _, hash := range []string{
+		"",
+		"Correct-Horse-42",
+		"$argon2i$v=19$m=65536,t=3,p=4$" + salt + "$" + key,
+		"$argon2id$",
+		"$argon2id$v=19$m=65536,t=3,p=4$" + salt,
+		"$argon2id$v=19$m=65536,t=3,p=4$" + salt + "$" + key + "$extra",
+		"$argon2id$v=16$m=65536,t=3,p=4$" + salt + "$" + key,
+		"$argon2id$v=19$m=65536,t=3$" + salt + "$" + key,
+		"$argon2id$v=19$m=65536,t=3,p=4$not*base64$" + key,
+		"$argon2id$v=19$m=65536,t=3,p=4$" + salt + "$not*base64",
+		"$argon2id$v=19$m=65536,t=3,p=4$" + salt + "$",
+	} {
+		ok, err := verifyPassword(hash, "Correct-Horse-42")
+		if ok || !errors.Is(err, errUnknownHash) {
+			t.Errorf("verifying against %q: got %v, %v; want errUnknownHash", hash, ok, err)
+		}
+	}
+
+	// bcrypt reports its own errors for truncated hashes
+	if ok, err := verifyPassword("$2a$12$tooshort", "Correct-Horse-42"); ok || err == nil {
+		t.Errorf("verifying against a truncated bcrypt hash: got %v, %v; want an error", ok, err)
+	}
+}
+
+// TestCheckPasswordStrength checks which passwords are refused as weak.
+func TestCheckPasswordStrength(t *testing.T) {
+	for password, ok := range map[string]bool{
+		"Short-1":                  false,
+		"alice-Secret-42":          false,
+		"Password1234":             false,
+		"aaaaaaaaaaaaAAAA1111":     false,
+		"onlylowercaseletters":     true,
+		"onlylowercase":            false,
+		"Zebra-Quartz-917!":        true,
+		"correct horse battery st": true,
+	} {
+		err := checkPasswordStrength("alice", password)
+		if (err == nil) != ok {
+			t.Errorf("%q: got %v, want ok=%v", password, err, ok)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"country_assignment_api/countrypb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// encodeProtobuf encodes a protobuf message in the binary wire format.
+func encodeProtobuf(data interface{}) ([]byte, error) {
+	message, ok := data.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T has no protobuf form", data)
+	}
+	return proto.Marshal(message)
+}
+
+// protoCountries converts countries to protobuf messages, keeping only the projected fields.
+func protoCountries(countries []Country, fields fieldProjection) ([]*countrypb.Country, error) {
+	messages := make([]*countrypb.Country, 0, len(countries))
+	for _, country := range countries {
+		projected, err := projectCountry(country, fields)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, countryToProto(projected))
+	}
+	return messages, nil
+}
+
+// protoMatches converts name matches to protobuf messages, keeping only the projected fields.
+func protoMatches(matches []CountryMatch, fields fieldProjection) ([]*countrypb.CountryMatch, error) {
+	messages := make([]*countrypb.CountryMatch, 0, len(matches))
+	for _, match := range matches {
+		projected, err := projectCountry(match.Country, fields)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &countrypb.CountryMatch{Country: countryToProto(projected), Score: match.Score})
+	}
+	return messages, nil
+}
+
+// projectCountry returns a copy of country with only the projected fields set.
+func projectCountry(country Country, fields fieldProjection) (Country, error) {
+	if fields == nil {
+		return country, nil
+	}
+
+	projected, err := fields.apply(country)
+	if err != nil {
+		return Country{}, err
+	}
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return Country{}, err
+	}
+
+	var result Country
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Country{}, err
+	}
+	return result, nil
+}
+
+// countryToProto converts a country to its protobuf message.
+func countryToProto(c Country) *countrypb.Country {
+	message := &countrypb.Country{
+		Name: &countrypb.CountryName{
+			Common:     c.Name.Common,
+			Official:   c.Name.Official,
+			NativeName: nativeNamesToProto(c.Name.NativeName),
+		},
+		Tld:         c.TLD,
+		Cca2:        c.CCA2,
+		Ccn3:        c.CCN3,
+		Cca3:        c.CCA3,
+		Cioc:        c.CIOC,
+		Independent: c.Independent,
+		Status:      c.Status,
+		UnMember:    c.UNMember,
+		Idd: &countrypb.Idd{
+			Root:     c.IDD.Root,
+			Suffixes: c.IDD.Suffixes,
+		},
+		Capital:      c.Capital,
+		AltSpellings: c.AltSpellings,
+		Region:       c.Region,
+		Subregion:    c.Subregion,
+		Languages:    c.Languages,
+		Translations: nativeNamesToProto(c.Translations),
+		Latlng:       c.LatLng,
+		Landlocked:   c.Landlocked,
+		Borders:      c.Borders,
+		Area:         c.Area,
+		Flag:         c.Flag,
+		Maps: &countrypb.Maps{
+			GoogleMaps:     c.Maps.GoogleMaps,
+			OpenStreetMaps: c.Maps.OpenStreetMaps,
+		},
+		Population: c.Population,
+		Gini:       c.Gini,
+		Fifa:       c.FIFA,
+		Car: &countrypb.Car{
+			Signs: c.Car.Signs,
+			Side:  c.Car.Side,
+		},
+		Timezones:   c.Timezones,
+		Continents:  c.Continents,
+		Flags:       imagesToProto(c.Flags),
+		CoatOfArms:  imagesToProto(c.CoatOfArms),
+		StartOfWeek: c.StartOfWeek,
+		CapitalInfo: &countrypb.CapitalInfo{Latlng: c.CapitalInfo.LatLng},
+	}
+
+	if c.Currencies != nil {
+		message.Currencies = make(map[string]*countrypb.Currency, len(c.Currencies))
+		for code, currency := range c.Currencies {
+			message.Currencies[code] = &countrypb.Currency{Name: currency.Name, Symbol: currency.Symbol}
+		}
+	}
+	if c.Demonyms != nil {
+		message.Demonyms = make(map[string]*countrypb.Demonym, len(c.Demonyms))
+		for lang, demonym := range c.Demonyms {
+			message.Demonyms[lang] = &countrypb.Demonym{F: demonym.F, M: demonym.M}
+		}
+	}
+	if c.PostalCode != nil {
+		message.PostalCode = &countrypb.PostalCode{Format: c.PostalCode.Format, Regex: c.PostalCode.Regex}
+	}
+
+	// Leave out nested messages with nothing set, such as those dropped by a projection
+	if proto.Size(message.Name) == 0 {
+		message.Name = nil
+	}
+	if proto.Size(message.Idd) == 0 {
+		message.Idd = nil
+	}
+	if proto.Size(message.Maps) == 0 {
+		message.Maps = nil
+	}
+	if proto.Size(message.Car) == 0 {
+		message.Car = nil
+	}
+	if proto.Size(message.Flags) == 0 {
+		message.Flags = nil
+	}
+	if proto.Size(message.CoatOfArms) == 0 {
+		message.CoatOfArms = nil
+	}
+	if proto.Size(message.CapitalInfo) == 0 {
+		message.CapitalInfo = nil
+	}
+
+	return message
+}
+
+// countryFromProto converts a protobuf message back to a country.
+func countryFromProto(m *countrypb.Country) Country {
+	c := Country{
+		Name: CountryName{
+			Common:     m.GetName().GetCommon(),
+			Official:   m.GetName().GetOfficial(),
+			NativeName: nativeNamesFromProto(m.GetName().GetNativeName()),
+		},
+		TLD:         m.GetTld(),
+		CCA2:        m.GetCca2(),
+		CCN3:        m.GetCcn3(),
+		CCA3:        m.GetCca3(),
+		CIOC:        m.GetCioc(),
+		Independent: m.Independent,
+		Status:      m.GetStatus(),
+		UNMember:    m.GetUnMember(),
+		IDD: IDD{
+			Root:     m.GetIdd().GetRoot(),
+			Suffixes: m.GetIdd().GetSuffixes(),
+		},
+		Capital:      m.GetCapital(),
+		AltSpellings: m.GetAltSpellings(),
+		Region:       m.GetRegion(),
+		Subregion:    m.GetSubregion(),
+		Languages:    m.GetLanguages(),
+		Translations: nativeNamesFromProto(m.GetTranslations()),
+		LatLng:       m.GetLatlng(),
+		Landlocked:   m.GetLandlocked(),
+		Borders:      m.GetBorders(),
+		Area:         m.GetArea(),
+		Flag:         m.GetFlag(),
+		Maps: Maps{
+			GoogleMaps:     m.GetMaps().GetGoogleMaps(),
+			OpenStreetMaps: m.GetMaps().GetOpenStreetMaps(),
+		},
+		Population: m.GetPopulation(),
+		Gini:       m.GetGini(),
+		FIFA:       m.GetFifa(),
+		Car: Car{
+			Signs: m.GetCar().GetSigns(),
+			Side:  m.GetCar().GetSide(),
+		},
+		Timezones:   m.GetTimezones(),
+		Continents:  m.GetContinents(),
+		Flags:       imagesFromProto(m.GetFlags()),
+		CoatOfArms:  imagesFromProto(m.GetCoatOfArms()),
+		StartOfWeek: m.GetStartOfWeek(),
+		CapitalInfo: CapitalInfo{LatLng: m.GetCapitalInfo().GetLatlng()},
+	}
+
+	if m.GetCurrencies() != nil {
+		c.Currencies = make(map[string]Currency, len(m.GetCurrencies()))
+		for code, currency := range m.GetCurrencies() {
+			c.Currencies[code] = Currency{Name: currency.GetName(), Symbol: currency.GetSymbol()}
+		}
+	}
+	if m.GetDemonyms() != nil {
+		c.Demonyms = make(map[string]Demonym, len(m.GetDemonyms()))
+		for lang, demonym := range m.GetDemonyms() {
+			c.Demonyms[lang] = Demonym{F: demonym.GetF(), M: demonym.GetM()}
+		}
+	}
+	if m.PostalCode != nil {
+		c.PostalCode = &PostalCode{Format: m.PostalCode.GetFormat(), Regex: m.PostalCode.GetRegex()}
+	}
+
+	return c
+}
+
+func nativeNamesToProto(names map[string]NativeName) map[string]*countrypb.NativeName {
+	if names == nil {
+		return nil
+	}
+	result := make(map[string]*countrypb.NativeName, len(names))
+	for lang, name := range names {
+		result[lang] = &countrypb.NativeName{Official: name.Official, Common: name.Common}
+	}
+	return result
+}
+
+func nativeNamesFromProto(names map[string]*countrypb.NativeName) map[string]NativeName {
+	if names == nil {
+		return nil
+	}
+	result := make(map[string]NativeName, len(names))
+	for lang, name := range names {
+		result[lang] = NativeName{Official: name.GetOfficial(), Common: name.GetCommon()}
+	}
+	return result
+}
+
+func imagesToProto(images Images) *countrypb.Images {
+	return &countrypb.Images{Png: images.PNG, Svg: images.SVG, Alt: images.Alt}
+}
+
+func imagesFromProto(images *countrypb.Images) Images {
+	return Images{PNG: images.GetPng(), SVG: images.GetSvg(), Alt: images.GetAlt()}
+}
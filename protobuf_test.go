@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"country_assignment_api/countrypb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCountryProtobufRoundTrip checks that every embedded country survives a trip through the
+// protobuf wire format with the same JSON form it started with.
+func TestCountryProtobufRoundTrip(t *testing.T) {
+	countries, err := loadEmbeddedCountries()
+	if err != nil {
+		t.Fatalf("loading embedded countries: %v", err)
+	}
+
+	for _, country := range countries {
+		want, err := json.Marshal(country)
+		if err != nil {
+			t.Fatalf("%s: marshaling JSON: %v", country.CCA3, err)
+		}
+
+		wire, err := proto.Marshal(countryToProto(country))
+		if err != nil {
+			t.Fatalf("%s: marshaling protobuf: %v", country.CCA3, err)
+		}
+		var message countrypb.Country
+		if err := proto.Unmarshal(wire, &message); err != nil {
+			t.Fatalf("%s: unmarshaling protobuf: %v", country.CCA3, err)
+		}
+
+		got, err := json.Marshal(countryFromProto(&message))
+		if err != nil {
+			t.Fatalf("%s: marshaling round-tripped JSON: %v", country.CCA3, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: JSON differs after protobuf round trip\n got: %s\nwant: %s", country.CCA3, got, want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errCountryNotFound is returned by providers when no country matches a lookup.
+var errCountryNotFound = errors.New("country not found")
+
+// CountryProvider is a source of country data in the restcountries v3.1 model.
+type CountryProvider interface {
+	// All returns every country known to the provider.
+	All(ctx context.Context) ([]Country, error)
+	// ByName returns the countries whose common or official name contains name, case-insensitively.
+	ByName(ctx context.Context, name string) ([]Country, error)
+	// ByCode returns the country with the given cca2, cca3, ccn3 or cioc code.
+	ByCode(ctx context.Context, code string) (Country, error)
+}
+
+// newCountryProvider returns the provider selected by the configuration.
+func newCountryProvider(cfg Config) (CountryProvider, error) {
+	switch cfg.CountrySource {
+	case sourceFile:
+		return NewFileProvider(cfg.SnapshotFile), nil
+	case sourceEmbedded:
+		countries, err := loadEmbeddedCountries()
+		if err != nil {
+			return nil, fmt.Errorf("decoding embedded dataset: %w", err)
+		}
+		return NewMemoryProvider(countries), nil
+	default:
+		return NewRESTCountriesProvider(cfg.RESTCountriesURL), nil
+	}
+}
+
+// RESTCountriesProvider fetches countries from a restcountries v3.1 compatible HTTP API.
+type RESTCountriesProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewRESTCountriesProvider creates a provider for the API rooted at baseURL, e.g. https://restcountries.com/v3.1.
+func NewRESTCountriesProvider(baseURL string) *RESTCountriesProvider {
+	return &RESTCountriesProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// All fetches every country from the /all endpoint.
+func (p *RESTCountriesProvider) All(ctx context.Context) ([]Country, error) {
+	return p.fetch(ctx, "/all")
+}
+
+// ByName fetches the countries matching name from the /name endpoint.
+func (p *RESTCountriesProvider) ByName(ctx context.Context, name string) ([]Country, error) {
+	return p.fetch(ctx, "/name/"+url.PathEscape(name))
+}
+
+// ByCode fetches the country with the given code from the /alpha endpoint.
+func (p *RESTCountriesProvider) ByCode(ctx context.Context, code string) (Country, error) {
+	countries, err := p.fetch(ctx, "/alpha/"+url.PathEscape(code))
+	if err != nil {
+		return Country{}, err
+	}
+	if len(countries) == 0 {
+		return Country{}, errCountryNotFound
+	}
+
+	return countries[0], nil
+}
+
+// fetch retrieves and decodes a list of countries from path below the base URL.
+func (p *RESTCountriesProvider) fetch(ctx context.Context, path string) ([]Country, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errCountryNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+
+	countries, err := decodeCountries(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding country data: %w", err)
+	}
+
+	return countries, nil
+}
+
+// FileProvider reads countries from a restcountries v3.1 JSON snapshot on disk.
+// The file is re-read on every call so a replaced snapshot is picked up on the next refresh.
+type FileProvider struct {
+	path string
+}
+
+// NewFileProvider creates a provider backed by the snapshot file at path.
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+// All reads every country from the snapshot file.
+func (p *FileProvider) All(ctx context.Context) ([]Country, error) {
+	return loadSnapshotFile(p.path)
+}
+
+// ByName returns the countries in the snapshot whose name contains name.
+func (p *FileProvider) ByName(ctx context.Context, name string) ([]Country, error) {
+	countries, err := p.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonEmptyMatches(countriesByName(countries, name))
+}
+
+// ByCode returns the country in the snapshot with the given code.
+func (p *FileProvider) ByCode(ctx context.Context, code string) (Country, error) {
+	countries, err := p.All(ctx)
+	if err != nil {
+		return Country{}, err
+	}
+
+	return countryByCode(countries, code)
+}
+
+// MemoryProvider serves a fixed set of countries from memory. It backs the embedded
+// dataset and doubles as a fake in tests; SetErr makes every call fail.
+type MemoryProvider struct {
+	mu        sync.RWMutex
+	countries []Country
+	err       error
+}
+
+// NewMemoryProvider creates a provider serving countries.
+func NewMemoryProvider(countries []Country) *MemoryProvider {
+	return &MemoryProvider{countries: countries}
+}
+
+// SetCountries replaces the served countries.
+func (p *MemoryProvider) SetCountries(countries []Country) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.countries = countries
+}
+
+// SetErr makes every call fail with err until it is cleared with nil.
+func (p *MemoryProvider) SetErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+// All returns a copy of the served countries.
+func (p *MemoryProvider) All(ctx context.Context) ([]Country, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	countries := make([]Country, len(p.countries))
+	copy(countries, p.countries)
+	return countries, nil
+}
+
+// ByName returns the served countries whose name contains name.
+func (p *MemoryProvider) ByName(ctx context.Context, name string) ([]Country, error) {
+	countries, err := p.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nonEmptyMatches(countriesByName(countries, name))
+}
+
+// ByCode returns the served country with the given code.
+func (p *MemoryProvider) ByCode(ctx context.Context, code string) (Country, error) {
+	countries, err := p.All(ctx)
+	if err != nil {
+		return Country{}, err
+	}
+
+	return countryByCode(countries, code)
+}
+
+// countriesByName returns the countries whose common or official name contains name, case-insensitively.
+func countriesByName(countries []Country, name string) []Country {
+	needle := strings.ToLower(name)
+
+	matches := []Country{}
+	for _, country := range countries {
+		if strings.Contains(strings.ToLower(country.Name.Common), needle) || strings.Contains(strings.ToLower(country.Name.Official), needle) {
+			matches = append(matches, country)
+		}
+	}
+
+	return matches
+}
+
+// countryByCode returns the country whose cca2, cca3, ccn3 or cioc code equals code, case-insensitively.
+func countryByCode(countries []Country, code string) (Country, error) {
+	for _, country := range countries {
+		if country.HasCode(code) {
+			return country, nil
+		}
+	}
+
+	return Country{}, errCountryNotFound
+}
+
+// nonEmptyMatches maps an empty match list to errCountryNotFound, mirroring the restcountries API.
+func nonEmptyMatches(countries []Country) ([]Country, error) {
+	if len(countries) == 0 {
+		return nil, errCountryNotFound
+	}
+
+	return countries, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// providerTestCountries is the small dataset served by the providers under test.
+var providerTestCountries = []Country{
+	{Name: CountryName{Common: "Norway", Official: "Kingdom of Norway"}, CCA2: "NO", CCA3: "NOR", CCN3: "578", CIOC: "NOR"},
+	{Name: CountryName{Common: "Sweden", Official: "Kingdom of Sweden"}, CCA2: "SE", CCA3: "SWE", CCN3: "752", CIOC: "SWE"},
+	{Name: CountryName{Common: "Switzerland", Official: "Swiss Confederation"}, CCA2: "CH", CCA3: "CHE", CCN3: "756", CIOC: "SUI"},
+}
+
+// TestLocalProviderLookups checks ByName and ByCode on the providers that filter locally.
+func TestLocalProviderLookups(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "countries.json")
+	if err := writeSnapshotFile(snapshot, providerTestCountries); err != nil {
+		t.Fatal(err)
+	}
+
+	providers := map[string]CountryProvider{
+		"memory": NewMemoryProvider(providerTestCountries),
+		"file":   NewFileProvider(snapshot),
+	}
+	for name, provider := range providers {
+		t.Run(name, func(t *testing.T) {
+			testCountryLookups(t, provider)
+		})
+	}
+}
+
+// testCountryLookups runs the lookups shared by every provider against providerTestCountries.
+func testCountryLookups(t *testing.T, provider CountryProvider) {
+	t.Helper()
+	ctx := context.Background()
+
+	all, err := provider.All(ctx)
+	if err != nil || len(all) != len(providerTestCountries) {
+		t.Fatalf("All: got %d countries, %v", len(all), err)
+	}
+
+	names := []struct {
+		name string
+		want []string
+	}{
+		{"norway", []string{"NOR"}},
+		{"KINGDOM", []string{"NOR", "SWE"}},
+		{"confederation", []string{"CHE"}},
+	}
+	for _, tt := range names {
+		matches, err := provider.ByName(ctx, tt.name)
+		if err != nil {
+			t.Errorf("ByName(%q): %v", tt.name, err)
+			continue
+		}
+		if got := countryCodes(matches); !slices.Equal(got, tt.want) {
+			t.Errorf("ByName(%q): got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if _, err := provider.ByName(ctx, "atlantis"); !errors.Is(err, errCountryNotFound) {
+		t.Errorf("ByName(atlantis): got %v, want errCountryNotFound", err)
+	}
+
+	for _, code := range []string{"ch", "CHE", "756", "SUI"} {
+		country, err := provider.ByCode(ctx, code)
+		if err != nil || country.CCA3 != "CHE" {
+			t.Errorf("ByCode(%q): got %s, %v", code, country.CCA3, err)
+		}
+	}
+	for _, code := range []string{"", "XX"} {
+		if _, err := provider.ByCode(ctx, code); !errors.Is(err, errCountryNotFound) {
+			t.Errorf("ByCode(%q): got %v, want errCountryNotFound", code, err)
+		}
+	}
+}
+
+// TestRESTCountriesProviderEndpoints checks that the restcountries provider calls the upstream
+// /all, /name and /alpha endpoints and maps a 404 to errCountryNotFound.
+func TestRESTCountriesProviderEndpoints(t *testing.T) {
+	responses := map[string]string{
+		"/v3.1/all":         `[{"name": {"common": "Norway"}, "cca3": "NOR"}, {"name": {"common": "Sweden"}, "cca3": "SWE"}]`,
+		"/v3.1/name/united": `[{"name": {"common": "United Kingdom"}, "cca3": "GBR"}, {"name": {"common": "United States"}, "cca3": "USA"}]`,
+		"/v3.1/alpha/ch":    `[{"name": {"common": "Switzerland"}, "cca3": "CHE"}]`,
+	}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"status": 404, "message": "Not Found"}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer upstream.Close()
+
+	ctx := context.Background()
+	provider := NewRESTCountriesProvider(upstream.URL + "/v3.1/")
+
+	all, err := provider.All(ctx)
+	if err != nil || !slices.Equal(countryCodes(all), []string{"NOR", "SWE"}) {
+		t.Errorf("All: got %v, %v", countryCodes(all), err)
+	}
+	matches, err := provider.ByName(ctx, "united")
+	if err != nil || !slices.Equal(countryCodes(matches), []string{"GBR", "USA"}) {
+		t.Errorf("ByName(united): got %v, %v", countryCodes(matches), err)
+	}
+	if _, err := provider.ByName(ctx, "atlantis"); !errors.Is(err, errCountryNotFound) {
+		t.Errorf("ByName(atlantis): got %v, want errCountryNotFound", err)
+	}
+	country, err := provider.ByCode(ctx, "ch")
+	if err != nil || country.CCA3 != "CHE" {
+		t.Errorf("ByCode(ch): got %s, %v", country.CCA3, err)
+	}
+	if _, err := provider.ByCode(ctx, "xx"); !errors.Is(err, errCountryNotFound) {
+		t.Errorf("ByCode(xx): got %v, want errCountryNotFound", err)
+	}
+}
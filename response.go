@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// xmlRootElement wraps every XML response body.
+const xmlRootElement = "response"
+
+// responseEncoders encode a response body in each format that has a general-purpose encoding.
+// CSV is written separately since it needs to know its columns.
+var responseEncoders = map[string]func(data interface{}) ([]byte, error){
+	formatJSON:     encodeJSON,
+	formatXML:      encodeXML,
+	formatYAML:     encodeYAML,
+	formatGeoJSON:  encodeJSON,
+	formatProtobuf: encodeProtobuf,
+}
+
+// formatContentTypes are the Content-Type headers written for each encoded format.
+var formatContentTypes = map[string]string{
+	formatJSON:     "application/json",
+	formatXML:      "application/xml; charset=utf-8",
+	formatYAML:     "application/yaml; charset=utf-8",
+	formatGeoJSON:  "application/geo+json",
+	formatProtobuf: "application/x-protobuf",
+}
+
+// writeResponse writes data with the given status as JSON, XML or YAML, as negotiated for the request.
+func writeResponse(w http.ResponseWriter, r *http.Request, status int, data interface{}) {
+	writeEncoded(w, bodyFormat(w, r), status, data)
+}
+
+// writeError writes an ErrorResponse with the given status, in the format negotiated for the request.
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeResponse(w, r, status, ErrorResponse{Error: message})
+}
+
+// bodyFormat returns the JSON, XML or YAML format requested, defaulting to JSON. A format
+// parameter naming another format, such as csv, falls back to JSON rather than failing.
+func bodyFormat(w http.ResponseWriter, r *http.Request) string {
+	addVary(w, "Accept")
+
+	format, err := responseFormat(r, bodyFormats)
+	if err != nil {
+		return formatJSON
+	}
+	return format
+}
+
+// writeEncoded encodes data in format and writes it with the given status. The body is encoded
+// before anything is written, so an encoding failure can still be reported as a 500.
+func writeEncoded(w http.ResponseWriter, format string, status int, data interface{}) {
+	body, err := responseEncoders[format](data)
+	if err != nil {
+		log.Printf("Error encoding %s response: %s", format, err)
+		format, status = formatJSON, http.StatusInternalServerError
+		body, _ = encodeJSON(ErrorResponse{Error: "Error encoding response"})
+	}
+
+	w.Header().Set("Content-Type", formatContentTypes[format])
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// addVary adds a request header to the Vary response header unless it is already listed.
+func addVary(w http.ResponseWriter, header string) {
+	for _, value := range w.Header().Values("Vary") {
+		for _, listed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(listed), header) {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", header)
+}
+
+// encodeJSON encodes data as a single line of JSON.
+func encodeJSON(data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeXML encodes data as XML by walking its JSON form, so field names and order match the JSON
+// response. Objects become elements named after their keys, list elements become <item> elements,
+// and keys that are not valid XML names, such as the years in gini, become <entry key="...">.
+func encodeXML(data interface{}) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	if err := writeXMLValue(encoder, decoder, xmlRootElement); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), nil
+}
+
+// writeXMLValue reads the next JSON value from decoder and writes it as an element called name.
+func writeXMLValue(encoder *xml.Encoder, decoder *json.Decoder, name string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	start := xmlElement(name)
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		for decoder.More() {
+			childName := "item"
+			if t == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				childName = key.(string)
+			}
+			if err := writeXMLValue(encoder, decoder, childName); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	case nil:
+		// null is an empty element
+	default:
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(t))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// xmlElement returns the start element for a JSON key, falling back to <entry key="..."> for
+// keys that are not valid XML element names.
+func xmlElement(name string) xml.StartElement {
+	if isXMLName(name) {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "entry"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+	}
+}
+
+// isXMLName reports whether name can be used as an XML element name without a namespace.
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, ch := range name {
+		switch {
+		case unicode.IsLetter(ch) || ch == '_':
+		case i > 0 && (unicode.IsDigit(ch) || ch == '-' || ch == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// encodeYAML encodes data as block-style YAML by re-reading its JSON form as a YAML document,
+// which keeps the field names and order of the JSON response.
+func encodeYAML(data interface{}) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&document)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// clearYAMLStyle drops the flow and quoting styles JSON input produces, so the encoder picks
+// block style and only quotes strings where YAML requires it. Strings that YAML 1.1 parsers read
+// as something else, such as NO or on, stay quoted.
+func clearYAMLStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || !yaml11Ambiguous(node.Value) {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// yaml11Base60 matches the sexagesimal numbers of YAML 1.1, such as 1:30.
+var yaml11Base60 = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?$`)
+
+// yaml11Ambiguous reports whether a plain scalar s is a boolean or a number in YAML 1.1 but a
+// string in YAML 1.2. The encoder already quotes strings that YAML 1.2 would misread.
+func yaml11Ambiguous(s string) bool {
+	switch s {
+	case "y", "Y", "yes", "Yes", "YES", "on", "On", "ON",
+		"n", "N", "no", "No", "NO", "off", "Off", "OFF":
+		return true
+	}
+	return yaml11Base60.MatchString(s)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	yaml11 "gopkg.in/yaml.v2"
+)
+
+// TestEncodeYAMLQuotesAmbiguousStrings checks that strings which YAML 1.1 parsers would read as
+// booleans or numbers are quoted, while plain strings are not.
+func TestEncodeYAMLQuotesAmbiguousStrings(t *testing.T) {
+	data := map[string]interface{}{
+		"cca2":   "NO",
+		"on":     "on",
+		"yes":    "Y",
+		"root":   "+1",
+		"time":   "1:30",
+		"plain":  "Norway",
+		"number": 5,
+		"flag":   true,
+	}
+
+	out, err := encodeYAML(data)
+	if err != nil {
+		t.Fatalf("encoding YAML: %v", err)
+	}
+	if !strings.Contains(string(out), "plain: Norway\n") {
+		t.Errorf("plain string is quoted:\n%s", out)
+	}
+
+	// gopkg.in/yaml.v2 resolves scalars by YAML 1.1 rules, like PyYAML
+	var decoded map[string]interface{}
+	if err := yaml11.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("decoding YAML: %v\n%s", err, out)
+	}
+	for key, want := range data {
+		if decoded[key] != want {
+			t.Errorf("%s: got %#v (%T), want %#v\n%s", key, decoded[key], decoded[key], want, out)
+		}
+	}
+}
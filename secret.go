@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// minSecretLength is the shortest HS256 signing secret accepted in production, in bytes.
+const minSecretLength = 32
+
+// secretCommandTimeout bounds how long a secret command may run.
+const secretCommandTimeout = 30 * time.Second
+
+// errNoSecret is returned by secret providers that have no secret to offer.
+var errNoSecret = errors.New("no JWT secret configured")
+
+// SecretProvider supplies the secret that tokens are signed with. Implementations can fetch it
+// from anywhere, such as a KMS or a secrets manager; Secret is called at startup and again on
+// every reload.
+type SecretProvider interface {
+	// Secret returns the current secret.
+	Secret(ctx context.Context) ([]byte, error)
+	// String describes where the secret comes from, for logs. It must not include the secret.
+	String() string
+}
+
+// newSecretProvider returns the secret provider selected by the configuration, or nil if no
+// secret source is configured.
+func newSecretProvider(cfg Config) SecretProvider {
+	switch {
+	case cfg.JWTSecretFile != "":
+		return FileSecretProvider{Path: cfg.JWTSecretFile}
+	case cfg.JWTSecretCommand != "":
+		return CommandSecretProvider{Command: cfg.JWTSecretCommand}
+	case cfg.JWTSecretEnv:
+		return EnvSecretProvider{Name: "JWT_SECRET"}
+	default:
+		return nil
+	}
+}
+
+// EnvSecretProvider reads the secret from an environment variable. A reload re-reads the
+// variable, which only changes if the process changes its own environment.
+type EnvSecretProvider struct {
+	Name string
+}
+
+// Secret returns the value of the environment variable.
+func (p EnvSecretProvider) Secret(ctx context.Context) ([]byte, error) {
+	value := os.Getenv(p.Name)
+	if value == "" {
+		return nil, errNoSecret
+	}
+	return []byte(value), nil
+}
+
+func (p EnvSecretProvider) String() string {
+	return "environment variable " + p.Name
+}
+
+// FileSecretProvider reads the secret from a file, such as a mounted Kubernetes or Docker
+// secret. A trailing newline is ignored.
+type FileSecretProvider struct {
+	Path string
+}
+
+// Secret reads the file.
+func (p FileSecretProvider) Secret(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(data, "\r\n"), nil
+}
+
+func (p FileSecretProvider) String() string {
+	return "file " + p.Path
+}
+
+// CommandSecretProvider runs a shell command and uses its standard output as the secret, so
+// the secret can come from a KMS or secrets manager CLI, e.g.
+// "vault kv get -field=jwt secret/country-api". A trailing newline is ignored.
+type CommandSecretProvider struct {
+	Command string
+}
+
+// Secret runs the command.
+func (p CommandSecretProvider) Secret(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.Command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running secret command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return bytes.TrimRight(out, "\r\n"), nil
+}
+
+func (p CommandSecretProvider) String() string {
+	return "command"
+}
+
+// SecretStore holds the current signing key and reloads it from its provider.
+type SecretStore struct {
+	provider   SecretProvider
+	algorithm  string
+	production bool
+
+	mu  sync.RWMutex
+	key *signingKey
+}
+
+// NewSecretStore creates a store loading algorithm keys from provider, which may be nil. In
+// production a missing or weak key is an error; otherwise a missing key is replaced by a random
+// one, which does not survive restarts.
+func NewSecretStore(provider SecretProvider, algorithm string, production bool) *SecretStore {
+	return &SecretStore{provider: provider, algorithm: algorithm, production: production}
+}
+
+// Load fetches the signing material from the provider and makes its key current. On failure
+// the current key is kept.
+func (s *SecretStore) Load(ctx context.Context) error {
+	key, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.key = key
+	s.mu.Unlock()
+	return nil
+}
+
+// fetch reads and parses a key from the provider.
+func (s *SecretStore) fetch(ctx context.Context) (*signingKey, error) {
+	if s.provider == nil {
+		if s.production {
+			return nil, fmt.Errorf("%w: set JWT_SECRET, JWT_SECRET_FILE or JWT_SECRET_COMMAND", errNoSecret)
+		}
+
+		if current := s.Key(); current != nil {
+			return current, nil
+		}
+
+		log.Printf("Warning: no JWT secret configured, using a random %s key; tokens will not survive a restart", s.algorithm)
+		material, err := generateSigningMaterial(s.algorithm)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(s.algorithm, material, false)
+	}
+
+	material, err := s.provider.Secret(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading JWT secret from %s: %w", s.provider, err)
+	}
+	if len(material) == 0 {
+		return nil, fmt.Errorf("loading JWT secret from %s: %w", s.provider, errNoSecret)
+	}
+
+	key, err := newSigningKey(s.algorithm, material, s.production)
+	if err != nil {
+		return nil, fmt.Errorf("loading JWT secret from %s: %w", s.provider, err)
+	}
+	return key, nil
+}
+
+// Key returns the current key, which new tokens are signed with.
+func (s *SecretStore) Key() *signingKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.key
+}
+
+// Lookup returns the key with the given id, if tokens signed with it are still accepted.
+func (s *SecretStore) Lookup(id string) (*signingKey, bool) {
+	key := s.Key()
+	if key == nil || key.id != id {
+		return nil, false
+	}
+	return key, true
+}
+
+// VerificationKeys returns every key that tokens are accepted from.
+func (s *SecretStore) VerificationKeys() []*signingKey {
+	if key := s.Key(); key != nil {
+		return []*signingKey{key}
+	}
+	return nil
+}
+
+// ReloadOnSignal reloads the key whenever the process receives SIGHUP, until ctx is done.
+// Tokens signed with the previous key stop validating once a new key is loaded.
+func (s *SecretStore) ReloadOnSignal(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if err := s.Load(ctx); err != nil {
+				log.Printf("Reloading JWT secret failed, keeping the current one: %s", err)
+				continue
+			}
+			log.Printf("Reloaded JWT secret")
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestSecretProviders checks the env, file and command providers.
+func TestSecretProviders(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwt")
+	if err := os.WriteFile(path, []byte("from-a-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_JWT_SECRET", "from-the-environment")
+	t.Setenv("TEST_EMPTY_SECRET", "")
+
+	tests := []struct {
+		provider SecretProvider
+		want     string
+		wantErr  string
+	}{
+		{EnvSecretProvider{Name: "TEST_JWT_SECRET"}, "from-the-environment", ""},
+		{EnvSecretProvider{Name: "TEST_EMPTY_SECRET"}, "", errNoSecret.Error()},
+		{FileSecretProvider{Path: path}, "from-a-file", ""},
+		{FileSecretProvider{Path: filepath.Join(dir, "missing")}, "", "no such file"},
+		{CommandSecretProvider{Command: "printf 'from-a-command\\r\\n'"}, "from-a-command", ""},
+		{CommandSecretProvider{Command: "echo vault is sealed >&2; exit 2"}, "", "vault is sealed"},
+	}
+	for _, tt := range tests {
+		secret, err := tt.provider.Secret(ctx)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: got %q, %v, want error %q", tt.provider, secret, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || string(secret) != tt.want {
+			t.Errorf("%s: got %q, %v, want %q", tt.provider, secret, err, tt.want)
+		}
+		if strings.Contains(tt.provider.String(), tt.want) {
+			t.Errorf("%s: the description reveals the secret", tt.provider)
+		}
+	}
+}
+
+// TestNewSecretProvider checks which provider the configuration selects.
+func TestNewSecretProvider(t *testing.T) {
+	tests := []struct {
+		cfg  Config
+		want SecretProvider
+	}{
+		{Config{}, nil},
+		{Config{JWTSecretEnv: true}, EnvSecretProvider{Name: "JWT_SECRET"}},
+		{Config{JWTSecretFile: "/run/secrets/jwt"}, FileSecretProvider{Path: "/run/secrets/jwt"}},
+		{Config{JWTSecretCommand: "cat jwt"}, CommandSecretProvider{Command: "cat jwt"}},
+	}
+	for _, tt := range tests {
+		if got := newSecretProvider(tt.cfg); got != tt.want {
+			t.Errorf("%+v: got %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+
+	t.Setenv("JWT_SECRET", "set")
+	t.Setenv("JWT_SECRET_FILE", "/run/secrets/jwt")
+	if _, err := loadConfig(); err == nil {
+		t.Error("two secret sources were accepted")
+	}
+}
+
+// TestSecretStoreLoad checks the length rules in development and production, and that a failed
+// load keeps the current secret.
+func TestSecretStoreLoad(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	strong := strings.Repeat("s", minSecretLength)
+	write := func(name, secret string) SecretProvider {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(secret), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return FileSecretProvider{Path: path}
+	}
+
+	tests := []struct {
+		name       string
+		provider   SecretProvider
+		production bool
+		wantErr    bool
+	}{
+		{"strong", write("strong", strong), true, false},
+		{"short in development", write("short", "short"), false, false},
+		{"short in production", write("short", "short"), true, true},
+		{"empty", write("empty", ""), false, true},
+		{"none in production", nil, true, true},
+		{"none in development", nil, false, false},
+	}
+	for _, tt := range tests {
+		store := NewSecretStore(tt.provider, algHS256, tt.production)
+		err := store.Load(ctx)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if !tt.wantErr && store.Key() == nil {
+			t.Errorf("%s: no key after loading", tt.name)
+		}
+	}
+
+	// A random development key stays the same across reloads
+	store := NewSecretStore(nil, algHS256, false)
+	if err := store.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	random := store.Key()
+	if secret := random.private.([]byte); len(secret) != minSecretLength {
+		t.Errorf("random secret of %d bytes, want %d", len(secret), minSecretLength)
+	}
+	if err := store.Load(ctx); err != nil || store.Key() != random {
+		t.Errorf("reloading changed the random key: %v", err)
+	}
+
+	// A failed reload keeps the current secret
+	path := filepath.Join(dir, "rotated")
+	store = NewSecretStore(FileSecretProvider{Path: path}, algHS256, true)
+	write("rotated", strong)
+	if err := store.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	current := store.Key()
+	os.Remove(path)
+	if err := store.Load(ctx); err == nil || store.Key() != current {
+		t.Errorf("after a failed reload: got key %s, %v, want %s", store.Key().id, err, current.id)
+	}
+}
+
+// TestSecretStoreReloadOnSignal checks that SIGHUP reloads the secret.
+func TestSecretStoreReloadOnSignal(t *testing.T) {
+	// Keep SIGHUP from terminating the test binary before ReloadOnSignal has subscribed
+	ignored := make(chan os.Signal, 1)
+	signal.Notify(ignored, syscall.SIGHUP)
+	defer signal.Stop(ignored)
+
+	path := filepath.Join(t.TempDir(), "jwt")
+	first, second := strings.Repeat("1", minSecretLength), strings.Repeat("2", minSecretLength)
+	if err := os.WriteFile(path, []byte(first), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := NewSecretStore(FileSecretProvider{Path: path}, algHS256, true)
+	if err := store.Load(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want, err := newSigningKey(algHS256, []byte(second), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.ReloadOnSignal(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	if err := os.WriteFile(path, []byte(second), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for store.Key().id != want.id {
+		if time.Now().After(deadline) {
+			t.Fatal("the secret was not reloaded on SIGHUP")
+		}
+		if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestTokensFollowTheSecret checks that tokens stop validating once the secret changes.
+func TestTokensFollowTheSecret(t *testing.T) {
+	useSigningKey(t, newTestSigningKey(t, algHS256))
+	token, err := generateToken("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validateToken(token); err != nil {
+		t.Fatalf("validating with the same secret: %v", err)
+	}
+
+	useSigningKey(t, newTestSigningKey(t, algHS256))
+	if _, err := validateToken(token); err == nil {
+		t.Error("a token signed with the previous secret was accepted")
+	}
+}
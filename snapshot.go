@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+// embeddedCountries is a sample dataset bundled into the binary, in restcountries v3.1 format. It
+// holds a subset of the countries, some with trimmed fields, and is meant for development and CI.
+//
+//go:embed data/countries.json
+var embeddedCountries []byte
+
+// loadEmbeddedCountries decodes the dataset bundled into the binary.
+func loadEmbeddedCountries() ([]Country, error) {
+	return decodeCountries(bytes.NewReader(embeddedCountries))
+}
+
+// loadSnapshotFile decodes a restcountries v3.1 JSON snapshot from disk.
+func loadSnapshotFile(path string) ([]Country, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	countries, err := decodeCountries(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding snapshot %s: %w", path, err)
+	}
+
+	return countries, nil
+}
+
+// writeSnapshotFile writes countries to path as a restcountries v3.1 JSON array.
+// The file is written to a temporary name first so readers never see a partial snapshot.
+func writeSnapshotFile(path string, countries []Country) error {
+	data, err := json.MarshalIndent(countries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, append(data, '\n'), 0o644)
+}
+
+// snapshotCommand captures the live upstream dataset into a snapshot file usable with COUNTRY_SOURCE=file.
+func snapshotCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "snapshot",
+		Usage: "Capture the country dataset from a live upstream into a JSON snapshot file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "output",
+				Aliases:  []string{"o"},
+				Usage:    "path of the snapshot file to write",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "url",
+				Usage:   "base URL of the restcountries v3.1 compatible API to capture",
+				Value:   defaultRESTCountriesURL,
+				EnvVars: []string{"RESTCOUNTRIES_URL"},
+			},
+		},
+		Action: func(c *cli.Context) error {
+			provider := NewRESTCountriesProvider(c.String("url"))
+
+			countries, err := provider.All(context.Background())
+			if err != nil {
+				return fmt.Errorf("fetching countries from %s: %w", c.String("url"), err)
+			}
+			if len(countries) == 0 {
+				return fmt.Errorf("upstream %s returned no countries", c.String("url"))
+			}
+
+			if err := writeSnapshotFile(c.String("output"), countries); err != nil {
+				return fmt.Errorf("writing snapshot: %w", err)
+			}
+
+			log.Printf("Wrote %d countries to %s", len(countries), c.String("output"))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortKey is one key of a sort specification such as "region,-population".
+type sortKey struct {
+	Field string
+	Desc  bool
+}
+
+// sortValue is the value a country sorts by for one field. Missing values always sort last.
+type sortValue struct {
+	Num     float64 `json:"n,omitempty"`
+	Str     string  `json:"s,omitempty"`
+	Missing bool    `json:"m,omitempty"`
+}
+
+// sortField extracts the value a country is ordered by.
+type sortField struct {
+	numeric bool
+	value   func(country Country) sortValue
+}
+
+// sortFields are the fields accepted by the sort parameter.
+var sortFields = map[string]sortField{
+	"name": {value: func(c Country) sortValue {
+		return stringSortValue(c.Name.Common)
+	}},
+	"official_name": {value: func(c Country) sortValue {
+		return stringSortValue(c.Name.Official)
+	}},
+	"region": {value: func(c Country) sortValue {
+		return stringSortValue(c.Region)
+	}},
+	"capital": {value: func(c Country) sortValue {
+		if len(c.Capital) == 0 {
+			return sortValue{Missing: true}
+		}
+		return stringSortValue(c.Capital[0])
+	}},
+	"population": {numeric: true, value: func(c Country) sortValue {
+		return sortValue{Num: float64(c.Population)}
+	}},
+	"area": {numeric: true, value: func(c Country) sortValue {
+		return sortValue{Num: c.Area}
+	}},
+	"density": {numeric: true, value: func(c Country) sortValue {
+		density, ok := c.Density()
+		return sortValue{Num: density, Missing: !ok}
+	}},
+	"gini": {numeric: true, value: func(c Country) sortValue {
+		gini, ok := c.LatestGini()
+		return sortValue{Num: gini, Missing: !ok}
+	}},
+}
+
+// defaultSortKeys orders countries by common name, as the filter endpoint always has.
+var defaultSortKeys = []sortKey{{Field: "name"}}
+
+// stringSortValue returns a case-insensitive sort value for s, treating an empty string as missing.
+func stringSortValue(s string) sortValue {
+	if s == "" {
+		return sortValue{Missing: true}
+	}
+	return sortValue{Str: strings.ToLower(s)}
+}
+
+// parseSortKeys parses a comma-separated sort specification. A leading "-" sorts that field
+// in descending order. The legacy values "asc" and "desc" sort by name.
+func parseSortKeys(spec string) ([]sortKey, error) {
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "", "asc":
+		return defaultSortKeys, nil
+	case "desc":
+		return []sortKey{{Field: "name", Desc: true}}, nil
+	}
+
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		key := sortKey{Field: strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")}
+		key.Desc = strings.HasPrefix(part, "-")
+
+		if _, ok := sortFields[key.Field]; !ok {
+			return nil, fmt.Errorf("invalid sort field %q: must be one of %s", key.Field, strings.Join(sortFieldNames(), ", "))
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("invalid sort: field %q is listed more than once", key.Field)
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// sortFieldNames returns the accepted sort fields in alphabetical order.
+func sortFieldNames() []string {
+	names := make([]string, 0, len(sortFields))
+	for name := range sortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortCountries orders countries by keys, breaking ties on cca3 so the order is deterministic.
+func sortCountries(countries []Country, keys []sortKey) {
+	sort.SliceStable(countries, func(i, j int) bool {
+		return compareCountries(countries[i], countries[j], keys) < 0
+	})
+}
+
+// compareCountries compares a and b by keys and then by cca3, returning -1, 0 or 1.
+func compareCountries(a, b Country, keys []sortKey) int {
+	for _, key := range keys {
+		field := sortFields[key.Field]
+		if c := compareSortValues(field.value(a), field.value(b), field.numeric, key.Desc); c != 0 {
+			return c
+		}
+	}
+
+	return strings.Compare(a.CCA3, b.CCA3)
+}
+
+// compareSortValues compares two values of one field. Missing values sort last in either direction.
+func compareSortValues(a, b sortValue, numeric, desc bool) int {
+	switch {
+	case a.Missing && b.Missing:
+		return 0
+	case a.Missing:
+		return 1
+	case b.Missing:
+		return -1
+	}
+
+	c := 0
+	if numeric {
+		switch {
+		case a.Num < b.Num:
+			c = -1
+		case a.Num > b.Num:
+			c = 1
+		}
+	} else {
+		c = strings.Compare(a.Str, b.Str)
+	}
+
+	if desc {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestParseSortKeys checks the sort specifications accepted and rejected by the sort parameter.
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []sortKey
+	}{
+		{"", []sortKey{{Field: "name"}}},
+		{"asc", []sortKey{{Field: "name"}}},
+		{" DESC ", []sortKey{{Field: "name", Desc: true}}},
+		{"-population", []sortKey{{Field: "population", Desc: true}}},
+		{"Region, -Population,+area", []sortKey{{Field: "region"}, {Field: "population", Desc: true}, {Field: "area"}}},
+	}
+	for _, tt := range tests {
+		got, err := parseSortKeys(tt.spec)
+		if err != nil {
+			t.Errorf("%q: %v", tt.spec, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"flag", "name,", ",", "population,-population", "--area"} {
+		if _, err := parseSortKeys(spec); err == nil {
+			t.Errorf("%q was accepted", spec)
+		}
+	}
+}
+
+// TestSortCountries checks multi-key sorts, that missing values sort last in both directions and
+// that ties are broken by cca3 whatever the input order.
+func TestSortCountries(t *testing.T) {
+	countries := []Country{
+		{CCA3: "NOR", Name: CountryName{Common: "Norway"}, Region: "Europe", Capital: StringList{"Oslo"}, Population: 5379475, Area: 323802, Gini: map[string]float64{"2018": 27.6}},
+		{CCA3: "ATA", Name: CountryName{Common: "Antarctica"}, Region: "Antarctic", Population: 1000, Area: 14000000},
+		{CCA3: "SWE", Name: CountryName{Common: "Sweden"}, Region: "Europe", Capital: StringList{"Stockholm"}, Population: 10353442, Area: 450295, Gini: map[string]float64{"2008": 25, "2018": 30}},
+		{CCA3: "UMI", Name: CountryName{Common: "United States Minor Outlying Islands"}, Region: "Americas", Population: 300},
+		{CCA3: "BVT", Name: CountryName{Common: "Bouvet Island"}, Region: "Antarctic", Population: 0, Area: 49},
+		{CCA3: "IND", Name: CountryName{Common: "India"}, Region: "Asia", Capital: StringList{"New Delhi"}, Population: 1380004385, Area: 3287590, Gini: map[string]float64{"2011": 35.7}},
+		{CCA3: "HMD", Name: CountryName{Common: "Heard Island and McDonald Islands"}, Region: "Antarctic", Population: 0, Area: 412},
+	}
+
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"", []string{"ATA", "BVT", "HMD", "IND", "NOR", "SWE", "UMI"}},
+		{"desc", []string{"UMI", "SWE", "NOR", "IND", "HMD", "BVT", "ATA"}},
+		{"population", []string{"BVT", "HMD", "UMI", "ATA", "NOR", "SWE", "IND"}},
+		{"-population", []string{"IND", "SWE", "NOR", "ATA", "UMI", "BVT", "HMD"}},
+		{"region,-population", []string{"UMI", "ATA", "BVT", "HMD", "IND", "SWE", "NOR"}},
+		{"capital", []string{"IND", "NOR", "SWE", "ATA", "BVT", "HMD", "UMI"}},
+		{"-capital", []string{"SWE", "NOR", "IND", "ATA", "BVT", "HMD", "UMI"}},
+		{"density", []string{"BVT", "HMD", "ATA", "NOR", "SWE", "IND", "UMI"}},
+		{"-gini", []string{"IND", "SWE", "NOR", "ATA", "BVT", "HMD", "UMI"}},
+	}
+	for _, tt := range tests {
+		keys, err := parseSortKeys(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		forward := slices.Clone(countries)
+		sortCountries(forward, keys)
+		if got := countryCodes(forward); !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.spec, got, tt.want)
+		}
+
+		reversed := slices.Clone(countries)
+		slices.Reverse(reversed)
+		sortCountries(reversed, keys)
+		if got := countryCodes(reversed); !slices.Equal(got, tt.want) {
+			t.Errorf("%q from reversed input: got %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// serveCommand runs the HTTP and gRPC servers. It is also what the binary does without a command.
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve the HTTP and gRPC APIs (the default without a command)",
+		Action: func(c *cli.Context) error {
+			return runServer()
+		},
+	}
+}
+
+// userCommand manages the accounts in the user store the server authenticates against. The
+// store is selected by the same USER_STORE and USER_STORE_PATH variables as the server.
+func userCommand() *cli.Command {
+	usernameArg := func(c *cli.Context) (string, error) {
+		if c.NArg() != 1 {
+			return "", fmt.Errorf("expected exactly one username, got %d arguments", c.NArg())
+		}
+		return c.Args().First(), nil
+	}
+
+	return &cli.Command{
+		Name:  "user",
+		Usage: "Manage the users that can authenticate against the API",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "Add a user, prompting for its password",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					username, err := usernameArg(c)
+					if err != nil {
+						return err
+					}
+					if err := validateUsername(username); err != nil {
+						return err
+					}
+
+					return withUserStore(func(ctx context.Context, store UserStore) error {
+						if _, err := store.Get(ctx, username); err == nil {
+							return fmt.Errorf("user %s already exists", username)
+						}
+
+						password, err := readNewPassword(c, username)
+						if err != nil {
+							return err
+						}
+						user, err := newUser(username, password)
+						if err != nil {
+							return err
+						}
+						if err := store.Create(ctx, user); err != nil {
+							if errors.Is(err, errUserExists) {
+								return fmt.Errorf("user %s already exists", username)
+							}
+							return err
+						}
+
+						fmt.Fprintf(c.App.Writer, "Added user %s\n", username)
+						return nil
+					})
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List the users and their status",
+				Action: func(c *cli.Context) error {
+					return withUserStore(func(ctx context.Context, store UserStore) error {
+						list, err := store.List(ctx)
+						if err != nil {
+							return err
+						}
+
+						tw := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+						fmt.Fprintln(tw, "USERNAME\tSTATUS\tFAILED LOGINS\tCREATED\tUPDATED")
+						for _, user := range list {
+							fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", user.Username, userStatus(user), user.FailedLogins,
+								user.CreatedAt.Format(time.RFC3339), user.UpdatedAt.Format(time.RFC3339))
+						}
+						return tw.Flush()
+					})
+				},
+			},
+			{
+				Name:      "disable",
+				Usage:     "Disable a user, rejecting its logins and the tokens already issued to it",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					return setUserDisabled(c, usernameArg, true)
+				},
+			},
+			{
+				Name:      "enable",
+				Usage:     "Enable a disabled user again",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					return setUserDisabled(c, usernameArg, false)
+				},
+			},
+			{
+				Name:      "lock",
+				Usage:     "Lock a user until it is unlocked; tokens already issued keep working",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					return setUserLocked(c, usernameArg, true)
+				},
+			},
+			{
+				Name:      "unlock",
+				Usage:     "Unlock a user, clearing its failed logins",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					return setUserLocked(c, usernameArg, false)
+				},
+			},
+			{
+				Name:      "passwd",
+				Usage:     "Reset a user's password, prompting for the new one, and unlock the account",
+				ArgsUsage: "<username>",
+				Action: func(c *cli.Context) error {
+					username, err := usernameArg(c)
+					if err != nil {
+						return err
+					}
+
+					return withUserStore(func(ctx context.Context, store UserStore) error {
+						user, err := getUser(ctx, store, username)
+						if err != nil {
+							return err
+						}
+
+						password, err := readNewPassword(c, username)
+						if err != nil {
+							return err
+						}
+						if user.PasswordHash, err = hashPassword(password); err != nil {
+							return err
+						}
+						user.Locked = false
+						user.LockedUntil = nil
+						user.FailedLogins = 0
+						user.UpdatedAt = time.Now().UTC()
+						if err := store.Update(ctx, user); err != nil {
+							return err
+						}
+
+						fmt.Fprintf(c.App.Writer, "Changed the password of user %s\n", username)
+						return nil
+					})
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete a user",
+				ArgsUsage: "<username>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "yes",
+						Aliases: []string{"y"},
+						Usage:   "delete without asking for confirmation",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					username, err := usernameArg(c)
+					if err != nil {
+						return err
+					}
+
+					return withUserStore(func(ctx context.Context, store UserStore) error {
+						if _, err := getUser(ctx, store, username); err != nil {
+							return err
+						}
+
+						if !c.Bool("yes") {
+							confirmed, err := confirm(c, fmt.Sprintf("Delete user %s?", username))
+							if err != nil {
+								return err
+							}
+							if !confirmed {
+								return errors.New("not deleted")
+							}
+						}
+
+						if err := store.Delete(ctx, username); err != nil {
+							return err
+						}
+						fmt.Fprintf(c.App.Writer, "Deleted user %s\n", username)
+						return nil
+					})
+				},
+			},
+		},
+	}
+}
+
+// withUserStore opens the configured user store, runs fn on it and closes it again.
+func withUserStore(fn func(ctx context.Context, store UserStore) error) error {
+	cfg, err := loadUserStoreConfig()
+	if err != nil {
+		return err
+	}
+	passwordHashAlgorithm = cfg.PasswordHash
+
+	store, err := newUserStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	return fn(context.Background(), store)
+}
+
+// setUserDisabled disables or enables the user named by the command's argument.
+func setUserDisabled(c *cli.Context, usernameArg func(*cli.Context) (string, error), disabled bool) error {
+	username, err := usernameArg(c)
+	if err != nil {
+		return err
+	}
+
+	return withUserStore(func(ctx context.Context, store UserStore) error {
+		user, err := getUser(ctx, store, username)
+		if err != nil {
+			return err
+		}
+
+		user.Disabled = disabled
+		user.UpdatedAt = time.Now().UTC()
+		if err := store.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if disabled {
+			fmt.Fprintf(c.App.Writer, "Disabled user %s\n", username)
+		} else {
+			fmt.Fprintf(c.App.Writer, "Enabled user %s\n", username)
+		}
+		return nil
+	})
+}
+
+// setUserLocked locks or unlocks the user named by the command's argument. Unlocking also
+// ends a lockout after failed logins.
+func setUserLocked(c *cli.Context, usernameArg func(*cli.Context) (string, error), locked bool) error {
+	username, err := usernameArg(c)
+	if err != nil {
+		return err
+	}
+
+	return withUserStore(func(ctx context.Context, store UserStore) error {
+		user, err := getUser(ctx, store, username)
+		if err != nil {
+			return err
+		}
+
+		user.Locked = locked
+		if !locked {
+			user.LockedUntil = nil
+			user.FailedLogins = 0
+		}
+		user.UpdatedAt = time.Now().UTC()
+		if err := store.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if locked {
+			fmt.Fprintf(c.App.Writer, "Locked user %s\n", username)
+		} else {
+			fmt.Fprintf(c.App.Writer, "Unlocked user %s\n", username)
+		}
+		return nil
+	})
+}
+
+// getUser returns the named user, with a readable error if there is none.
+func getUser(ctx context.Context, store UserStore, username string) (User, error) {
+	user, err := store.Get(ctx, username)
+	if errors.Is(err, errUserNotFound) {
+		return User{}, fmt.Errorf("no user named %s", username)
+	}
+	return user, err
+}
+
+// userStatus describes whether a user can log in.
+func userStatus(user User) string {
+	switch {
+	case user.Disabled:
+		return "disabled"
+	case user.Locked:
+		return "locked"
+	case user.lockedAt(time.Now()):
+		return "locked until " + user.LockedUntil.Format(time.RFC3339)
+	default:
+		return "active"
+	}
+}
+
+// readNewPassword reads a new password for username and refuses weak ones. On a terminal it
+// prompts twice without echo; otherwise it reads a single line from standard input, so
+// passwords can be piped in from a secret manager.
+func readNewPassword(c *cli.Context, username string) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	var password string
+	if term.IsTerminal(fd) {
+		first, err := promptPassword(c.App.ErrWriter, fd, "New password: ")
+		if err != nil {
+			return "", err
+		}
+		if err := checkPasswordStrength(username, first); err != nil {
+			return "", err
+		}
+
+		second, err := promptPassword(c.App.ErrWriter, fd, "Repeat password: ")
+		if err != nil {
+			return "", err
+		}
+		if first != second {
+			return "", errors.New("passwords do not match")
+		}
+		password = first
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+			return "", fmt.Errorf("reading password from standard input: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if err := checkPasswordStrength(username, password); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// promptPassword prints prompt and reads a line from the terminal fd without echoing it.
+func promptPassword(w io.Writer, fd int, prompt string) (string, error) {
+	fmt.Fprint(w, prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(w)
+	if err != nil {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+	return string(password), nil
+}
+
+// confirm asks a yes/no question on the terminal. Without a terminal nothing is confirmed.
+func confirm(c *cli.Context, question string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, errors.New("refusing to ask for confirmation without a terminal, pass --yes")
+	}
+
+	fmt.Fprintf(c.App.ErrWriter, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
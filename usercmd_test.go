@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestUserCommands checks adding, listing, disabling, enabling, locking, unlocking, resetting
+// and deleting users through the user commands.
+func TestUserCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	t.Setenv("USER_STORE", userStoreFile)
+	t.Setenv("USER_STORE_PATH", path)
+	t.Setenv("PASSWORD_HASH", hashBcrypt)
+	// Server settings the user commands do not use are not checked
+	t.Setenv("COUNTRY_SOURCE", "nowhere")
+	store := NewFileUserStore(path)
+	ctx := context.Background()
+
+	steps := []struct {
+		stdin   string
+		args    string
+		output  string
+		wantErr string
+	}{
+		{testPassword + "\n", "user add bob", "Added user bob", ""},
+		{testPassword, "user add alice", "Added user alice", ""},
+		{testPassword + "\n", "user add bob", "", "already exists"},
+		{"short\n", "user add carol", "", "too short"},
+		{"", "user add carol", "", "reading password"},
+		{testPassword + "\n", "user add bad/name", "", "invalid username"},
+		{"", "user add", "", "exactly one username"},
+		{"", "user disable bob", "Disabled user bob", ""},
+		{"", "user list", "bob       disabled", ""},
+		{"", "user enable bob", "Enabled user bob", ""},
+		{"", "user disable nobody", "", "no user named nobody"},
+		{"", "user lock bob", "Locked user bob", ""},
+		{"", "user list", "bob       locked", ""},
+		{"", "user unlock bob", "Unlocked user bob", ""},
+		{"", "user unlock nobody", "", "no user named nobody"},
+		{"Another-Quartz-42\n", "user passwd bob", "Changed the password of user bob", ""},
+		{"", "user delete alice", "", "pass --yes"},
+		{"", "user delete --yes alice", "Deleted user alice", ""},
+		{"", "user delete --yes alice", "", "no user named alice"},
+	}
+	for _, step := range steps {
+		output, err := runCommand(t, step.stdin, strings.Fields(step.args)...)
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), step.wantErr) {
+				t.Errorf("%s: got error %v, want %q", step.args, err, step.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", step.args, err)
+		} else if !strings.Contains(output, step.output) {
+			t.Errorf("%s: printed %q, want %q", step.args, output, step.output)
+		}
+	}
+
+	list, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Username != "bob" || list[0].Disabled || list[0].Locked || !strings.HasPrefix(list[0].PasswordHash, "$2a$") {
+		t.Fatalf("users left: %+v", list)
+	}
+	if _, err := authenticate(ctx, store, "bob", "Another-Quartz-42"); err != nil {
+		t.Errorf("logging in with the new password: %v", err)
+	}
+}
+
+// TestUserPasswdUnlocks checks that resetting a password unlocks the account.
+func TestUserPasswdUnlocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	t.Setenv("USER_STORE_PATH", path)
+	store := NewFileUserStore(path)
+	ctx := context.Background()
+
+	bob := newTestUser(t, store, "bob")
+	until := time.Now().Add(time.Hour)
+	bob.Locked, bob.LockedUntil, bob.FailedLogins = true, &until, maxFailedLogins
+	if err := store.Update(ctx, bob); err != nil {
+		t.Fatal(err)
+	}
+	if output, _ := runCommand(t, "", "user", "list"); !strings.Contains(output, "locked") {
+		t.Errorf("list printed %q, want bob locked", output)
+	}
+
+	if _, err := runCommand(t, "Another-Quartz-42\n", "user", "passwd", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authenticate(ctx, store, "bob", "Another-Quartz-42"); err != nil {
+		t.Errorf("logging in after the reset: %v", err)
+	}
+	if _, err := authenticate(ctx, store, "bob", testPassword); !errors.Is(err, errInvalidCredentials) {
+		t.Errorf("logging in with the old password: got %v, want errInvalidCredentials", err)
+	}
+}
+
+// TestServeCommandChecksConfig checks that serve refuses an invalid configuration before
+// starting any listener.
+func TestServeCommandChecksConfig(t *testing.T) {
+	t.Setenv("COUNTRY_SOURCE", "nowhere")
+	if _, err := runCommand(t, "", "serve"); err == nil || !strings.Contains(err.Error(), "COUNTRY_SOURCE") {
+		t.Errorf("got %v, want an invalid COUNTRY_SOURCE error", err)
+	}
+}
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// User stores supported by USER_STORE.
+const (
+	userStoreFile   = "file"
+	userStoreSQLite = "sqlite"
+)
+
+// Failed login lockout: after maxFailedLogins consecutive failed logins an account is locked for
+// lockoutBase, doubling with every further failure up to lockoutMax.
+const (
+	maxFailedLogins = 5
+	lockoutBase     = time.Minute
+	lockoutMax      = time.Hour
+)
+
+var (
+	// errUserNotFound is returned by user stores when no user has the given username.
+	errUserNotFound = errors.New("user not found")
+	// errUserExists is returned by user stores when creating a user whose username is taken.
+	errUserExists = errors.New("user already exists")
+	// errInvalidCredentials is returned by authenticate for an unknown user or a wrong password.
+	errInvalidCredentials = errors.New("invalid credentials")
+	// errUserDisabled is returned by authenticate for a disabled account.
+	errUserDisabled = errors.New("account is disabled")
+	// errUserLocked is returned by authenticate for an account locked after too many failed logins.
+	errUserLocked = errors.New("account is locked")
+	// errSQLiteUnavailable is returned when the binary was built without cgo, which the SQLite
+	// driver needs.
+	errSQLiteUnavailable = errors.New("sqlite user store unavailable: the server was built without cgo, rebuild with CGO_ENABLED=1 or use USER_STORE=file")
+)
+
+// usernamePattern is the set of valid usernames.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]{0,63}$`)
+
+// users is the user store that logins and tokens are checked against.
+var users UserStore
+
+// User is an account that can authenticate against the API.
+type User struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+	// Disabled accounts cannot log in, and tokens already issued to them are rejected.
+	Disabled bool `json:"disabled"`
+	// Locked accounts cannot log in until an admin unlocks them.
+	Locked bool `json:"locked"`
+	// LockedUntil is when a lockout after repeated failed logins ends.
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+	FailedLogins int        `json:"failed_logins"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// lockedAt reports whether the user is locked, by an admin or by failed logins, at now.
+func (u User) lockedAt(now time.Time) bool {
+	return u.Locked || (u.LockedUntil != nil && now.Before(*u.LockedUntil))
+}
+
+// countFailedLogin adds a failed login to the user at now, locking the account once there have
+// been maxFailedLogins in a row.
+func (u *User) countFailedLogin(now time.Time) {
+	u.FailedLogins++
+	if lockout := lockoutDuration(u.FailedLogins); lockout > 0 {
+		until := now.Add(lockout).UTC()
+		u.LockedUntil = &until
+	}
+	u.UpdatedAt = now.UTC()
+}
+
+// lockoutDuration returns how long an account is locked after the given number of consecutive
+// failed logins, or zero if it stays unlocked.
+func lockoutDuration(failures int) time.Duration {
+	if failures < maxFailedLogins {
+		return 0
+	}
+	lockout := lockoutBase
+	for i := maxFailedLogins; i < failures && lockout < lockoutMax; i++ {
+		lockout *= 2
+	}
+	if lockout > lockoutMax {
+		lockout = lockoutMax
+	}
+	return lockout
+}
+
+// UserStore persists user accounts.
+type UserStore interface {
+	// Get returns the user with the given username.
+	Get(ctx context.Context, username string) (User, error)
+	// List returns every user, ordered by username.
+	List(ctx context.Context) ([]User, error)
+	// Create adds a new user, failing with errUserExists if the username is taken.
+	Create(ctx context.Context, user User) error
+	// Update replaces an existing user.
+	Update(ctx context.Context, user User) error
+	// RecordFailedLogin atomically counts a failed login of the user at now, locking the account
+	// from maxFailedLogins consecutive failures on, and returns the updated user.
+	RecordFailedLogin(ctx context.Context, username string, now time.Time) (User, error)
+	// ResetFailedLogins atomically clears the failed logins and any lockout of the user after a
+	// successful login at now. A lock set by an admin is kept.
+	ResetFailedLogins(ctx context.Context, username string, now time.Time) error
+	// Delete removes a user.
+	Delete(ctx context.Context, username string) error
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// newUserStore returns the user store selected by the configuration.
+func newUserStore(cfg Config) (UserStore, error) {
+	switch cfg.UserStore {
+	case userStoreSQLite:
+		return NewSQLiteUserStore(cfg.UserStorePath)
+	default:
+		return NewFileUserStore(cfg.UserStorePath), nil
+	}
+}
+
+// validateUsername checks that a username is 1-64 letters, digits or ._@- characters.
+func validateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("invalid username %q: use 1-64 letters, digits or . _ @ - characters, starting with a letter or digit", username)
+	}
+	return nil
+}
+
+// newUser returns a user with a freshly hashed password.
+func newUser(username, password string) (User, error) {
+	if err := validateUsername(username); err != nil {
+		return User{}, err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now().UTC()
+	return User{Username: username, PasswordHash: hash, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// authenticate checks a username and password against the store. Unknown users and wrong
+// passwords both yield errInvalidCredentials, and disabled accounts are only reported once the
+// password has been verified, so they do not reveal which usernames exist. A locked account is
+// reported without checking the password, so guesses made during a lockout tell nothing.
+func authenticate(ctx context.Context, store UserStore, username, password string) (User, error) {
+	user, err := store.Get(ctx, username)
+	if errors.Is(err, errUserNotFound) {
+		burnPasswordCheck(password)
+		return User{}, errInvalidCredentials
+	}
+	if err != nil {
+		return User{}, err
+	}
+
+	now := time.Now()
+	if user.lockedAt(now) {
+		burnPasswordCheck(password)
+		return User{}, errUserLocked
+	}
+
+	ok, err := verifyPassword(user.PasswordHash, password)
+	if err != nil {
+		return User{}, fmt.Errorf("checking password of %s: %w", username, err)
+	}
+
+	if !ok {
+		if _, err := store.RecordFailedLogin(ctx, username, now); err != nil {
+			return User{}, err
+		}
+		return User{}, errInvalidCredentials
+	}
+
+	if user.Disabled {
+		return User{}, errUserDisabled
+	}
+
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		if err := store.ResetFailedLogins(ctx, username, now); err != nil {
+			return User{}, err
+		}
+		user.FailedLogins = 0
+		user.LockedUntil = nil
+		user.UpdatedAt = now.UTC()
+	}
+
+	return user, nil
+}
+
+// bootstrapUser creates the given user if the store has no users at all, so a fresh deployment
+// can be logged into before anyone has been added.
+func bootstrapUser(ctx context.Context, store UserStore, username, password string) (bool, error) {
+	existing, err := store.List(ctx)
+	if err != nil || len(existing) > 0 {
+		return false, err
+	}
+
+	user, err := newUser(username, password)
+	if err != nil {
+		return false, err
+	}
+	if err := store.Create(ctx, user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FileUserStore keeps users in a JSON file. The file is re-read whenever it changes on disk, so
+// edits made by other processes, such as the user commands, are picked up by a running server.
+// Changes are serialized across processes by a lock file next to it, <path>.lock.
+type FileUserStore struct {
+	path string
+
+	mu      sync.Mutex
+	users   map[string]User
+	modTime time.Time
+	size    int64
+}
+
+// NewFileUserStore creates a store backed by the JSON file at path. A missing file is an empty store.
+func NewFileUserStore(path string) *FileUserStore {
+	return &FileUserStore{path: path}
+}
+
+// Get returns the user with the given username.
+func (s *FileUserStore) Get(ctx context.Context, username string) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return User{}, err
+	}
+	user, ok := s.users[username]
+	if !ok {
+		return User{}, errUserNotFound
+	}
+	return user, nil
+}
+
+// List returns every user, ordered by username.
+func (s *FileUserStore) List(ctx context.Context) ([]User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s.sorted(), nil
+}
+
+// Create adds a new user.
+func (s *FileUserStore) Create(ctx context.Context, user User) error {
+	unlock, err := s.lockForWrite()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.users[user.Username]; ok {
+		return errUserExists
+	}
+	s.users[user.Username] = user
+	return s.save()
+}
+
+// Update replaces an existing user.
+func (s *FileUserStore) Update(ctx context.Context, user User) error {
+	unlock, err := s.lockForWrite()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.users[user.Username]; !ok {
+		return errUserNotFound
+	}
+	s.users[user.Username] = user
+	return s.save()
+}
+
+// RecordFailedLogin counts a failed login of the user.
+func (s *FileUserStore) RecordFailedLogin(ctx context.Context, username string, now time.Time) (User, error) {
+	unlock, err := s.lockForWrite()
+	if err != nil {
+		return User{}, err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil {
+		return User{}, err
+	}
+	user, ok := s.users[username]
+	if !ok {
+		return User{}, errUserNotFound
+	}
+	user.countFailedLogin(now)
+	s.users[username] = user
+	if err := s.save(); err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// ResetFailedLogins clears the failed logins of the user.
+func (s *FileUserStore) ResetFailedLogins(ctx context.Context, username string, now time.Time) error {
+	unlock, err := s.lockForWrite()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	user, ok := s.users[username]
+	if !ok {
+		return errUserNotFound
+	}
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	user.UpdatedAt = now.UTC()
+	s.users[username] = user
+	return s.save()
+}
+
+// Delete removes a user.
+func (s *FileUserStore) Delete(ctx context.Context, username string) error {
+	unlock, err := s.lockForWrite()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.users[username]; !ok {
+		return errUserNotFound
+	}
+	delete(s.users, username)
+	return s.save()
+}
+
+// Close does nothing; the file is only open while it is read or written.
+func (s *FileUserStore) Close() error {
+	return nil
+}
+
+// lockForWrite takes the store's mutex and the lock file next to the store, so a change is never
+// interleaved with one made by another process, such as a user command run next to the server.
+// The file is re-read on the next load, as it may have changed within the modification time's
+// granularity. The returned function releases both locks.
+func (s *FileUserStore) lockForWrite() (func(), error) {
+	s.mu.Lock()
+	unlock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("locking user file %s: %w", s.path, err)
+	}
+
+	s.users = nil
+	return func() {
+		unlock()
+		s.mu.Unlock()
+	}, nil
+}
+
+// load re-reads the file if it changed since it was last read. s.mu must be held.
+func (s *FileUserStore) load() error {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.users = make(map[string]User)
+		s.modTime, s.size = time.Time{}, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.users != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	var list []User
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("decoding user file %s: %w", s.path, err)
+	}
+
+	s.users = make(map[string]User, len(list))
+	for _, user := range list {
+		s.users[user.Username] = user
+	}
+	s.modTime, s.size = info.ModTime(), info.Size()
+	return nil
+}
+
+// save writes the users to a temporary file and renames it over the store, so readers never
+// see a partial file. The file is only readable by its owner, as it holds password hashes.
+// s.mu must be held.
+func (s *FileUserStore) save() error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(s.path, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	// Force the next load to pick up the file as written
+	s.users = nil
+	return s.load()
+}
+
+// sorted returns the users ordered by username. s.mu must be held.
+func (s *FileUserStore) sorted() []User {
+	list := make([]User, 0, len(s.users))
+	for _, user := range s.users {
+		list = append(list, user)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Username < list[j].Username })
+	return list
+}
@@ -0,0 +1,223 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteUserSchema creates the users table of a SQLite user store.
+const sqliteUserSchema = `
+CREATE TABLE IF NOT EXISTS users (
+	username      TEXT PRIMARY KEY,
+	password_hash TEXT NOT NULL,
+	disabled      INTEGER NOT NULL DEFAULT 0,
+	locked        INTEGER NOT NULL DEFAULT 0,
+	failed_logins INTEGER NOT NULL DEFAULT 0,
+	created_at    TEXT NOT NULL,
+	updated_at    TEXT NOT NULL,
+	locked_until  TEXT
+)`
+
+// sqliteUserColumns are the columns scanned by scanUser, in order.
+const sqliteUserColumns = "username, password_hash, disabled, locked, failed_logins, created_at, updated_at, locked_until"
+
+// SQLiteUserStore keeps users in a SQLite database, which can be shared safely by the server
+// and the user commands.
+type SQLiteUserStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserStore opens the SQLite database at path, creating it and its users table if needed.
+func NewSQLiteUserStore(path string) (*SQLiteUserStore, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqliteUserSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating users table in %s: %w", path, err)
+	}
+	if err := addLockedUntilColumn(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("upgrading users table in %s: %w", path, err)
+	}
+	return &SQLiteUserStore{db: db}, nil
+}
+
+// addLockedUntilColumn adds the locked_until column to users tables created before it existed.
+func addLockedUntilColumn(db *sql.DB) error {
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('users') WHERE name = 'locked_until'").Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err := db.Exec("ALTER TABLE users ADD COLUMN locked_until TEXT")
+	return err
+}
+
+// Get returns the user with the given username.
+func (s *SQLiteUserStore) Get(ctx context.Context, username string) (User, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+sqliteUserColumns+" FROM users WHERE username = ?", username)
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, errUserNotFound
+	}
+	return user, err
+}
+
+// List returns every user, ordered by username.
+func (s *SQLiteUserStore) List(ctx context.Context) ([]User, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+sqliteUserColumns+" FROM users ORDER BY username")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, user)
+	}
+	return list, rows.Err()
+}
+
+// Create adds a new user.
+func (s *SQLiteUserStore) Create(ctx context.Context, user User) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO users ("+sqliteUserColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		user.Username, user.PasswordHash, user.Disabled, user.Locked, user.FailedLogins,
+		formatUserTime(user.CreatedAt), formatUserTime(user.UpdatedAt), formatLockedUntil(user.LockedUntil))
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+		return errUserExists
+	}
+	return err
+}
+
+// Update replaces an existing user.
+func (s *SQLiteUserStore) Update(ctx context.Context, user User) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE users SET password_hash = ?, disabled = ?, locked = ?, failed_logins = ?, created_at = ?, updated_at = ?, locked_until = ? WHERE username = ?",
+		user.PasswordHash, user.Disabled, user.Locked, user.FailedLogins,
+		formatUserTime(user.CreatedAt), formatUserTime(user.UpdatedAt), formatLockedUntil(user.LockedUntil), user.Username)
+	if err != nil {
+		return err
+	}
+	return requireAffectedRow(result)
+}
+
+// RecordFailedLogin counts a failed login of the user. The count is incremented in a single
+// statement, so concurrent failures are never lost; the lockout is then set by whichever
+// failure was counted last.
+func (s *SQLiteUserStore) RecordFailedLogin(ctx context.Context, username string, now time.Time) (User, error) {
+	var failures int
+	err := s.db.QueryRowContext(ctx,
+		"UPDATE users SET failed_logins = failed_logins + 1, updated_at = ? WHERE username = ? RETURNING failed_logins",
+		formatUserTime(now), username).Scan(&failures)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, errUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+
+	if lockout := lockoutDuration(failures); lockout > 0 {
+		_, err := s.db.ExecContext(ctx,
+			"UPDATE users SET locked_until = ? WHERE username = ? AND failed_logins = ?",
+			formatUserTime(now.Add(lockout)), username, failures)
+		if err != nil {
+			return User{}, err
+		}
+	}
+	return s.Get(ctx, username)
+}
+
+// ResetFailedLogins clears the failed logins of the user in a single statement, so it never
+// overwrites changes made since the user was read, such as an admin disabling the account.
+func (s *SQLiteUserStore) ResetFailedLogins(ctx context.Context, username string, now time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE users SET failed_logins = 0, locked_until = NULL, updated_at = ? WHERE username = ?",
+		formatUserTime(now), username)
+	if err != nil {
+		return err
+	}
+	return requireAffectedRow(result)
+}
+
+// Delete removes a user.
+func (s *SQLiteUserStore) Delete(ctx context.Context, username string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM users WHERE username = ?", username)
+	if err != nil {
+		return err
+	}
+	return requireAffectedRow(result)
+}
+
+// Close closes the database.
+func (s *SQLiteUserStore) Close() error {
+	return s.db.Close()
+}
+
+// scanUser reads a user from a row holding sqliteUserColumns.
+func scanUser(row interface{ Scan(...interface{}) error }) (User, error) {
+	var user User
+	var createdAt, updatedAt string
+	var lockedUntil sql.NullString
+	err := row.Scan(&user.Username, &user.PasswordHash, &user.Disabled, &user.Locked, &user.FailedLogins, &createdAt, &updatedAt, &lockedUntil)
+	if err != nil {
+		return User{}, err
+	}
+
+	if user.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return User{}, fmt.Errorf("invalid created_at of user %s: %w", user.Username, err)
+	}
+	if user.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+		return User{}, fmt.Errorf("invalid updated_at of user %s: %w", user.Username, err)
+	}
+	if lockedUntil.Valid {
+		until, err := time.Parse(time.RFC3339Nano, lockedUntil.String)
+		if err != nil {
+			return User{}, fmt.Errorf("invalid locked_until of user %s: %w", user.Username, err)
+		}
+		user.LockedUntil = &until
+	}
+	return user, nil
+}
+
+// requireAffectedRow returns errUserNotFound if a statement changed no rows.
+func requireAffectedRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errUserNotFound
+	}
+	return nil
+}
+
+// formatUserTime formats a timestamp for storage.
+func formatUserTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// formatLockedUntil formats an optional lockout end for storage, as NULL when there is none.
+func formatLockedUntil(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatUserTime(*t)
+}
@@ -0,0 +1,8 @@
+//go:build !cgo
+
+package main
+
+// NewSQLiteUserStore fails, since SQLite support needs cgo.
+func NewSQLiteUserStore(path string) (UserStore, error) {
+	return nil, errSQLiteUnavailable
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// forEachUserStore runs fn against an empty file store and an empty SQLite store. The SQLite
+// store is skipped in builds without cgo.
+func forEachUserStore(t *testing.T, fn func(t *testing.T, store UserStore)) {
+	t.Run("file", func(t *testing.T) {
+		fn(t, NewFileUserStore(filepath.Join(t.TempDir(), "users.json")))
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		store, err := NewSQLiteUserStore(filepath.Join(t.TempDir(), "users.db"))
+		if errors.Is(err, errSQLiteUnavailable) {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatalf("opening SQLite store: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		fn(t, store)
+	})
+}
+
+// TestUserStoreCRUD checks the basic operations of each store.
+func TestUserStoreCRUD(t *testing.T) {
+	forEachUserStore(t, func(t *testing.T, store UserStore) {
+		ctx := context.Background()
+		bob := newTestUser(t, store, "bob")
+		newTestUser(t, store, "alice")
+
+		if err := store.Create(ctx, bob); !errors.Is(err, errUserExists) {
+			t.Errorf("creating a duplicate: got %v, want errUserExists", err)
+		}
+
+		list, err := store.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 2 || list[0].Username != "alice" || list[1].Username != "bob" {
+			t.Errorf("List: got %+v, want alice and bob", list)
+		}
+
+		until := time.Now().Add(time.Minute).UTC().Truncate(time.Millisecond)
+		bob.Disabled, bob.LockedUntil, bob.FailedLogins = true, &until, 3
+		if err := store.Update(ctx, bob); err != nil {
+			t.Fatalf("updating: %v", err)
+		}
+		got, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Disabled || got.FailedLogins != 3 || got.LockedUntil == nil || !got.LockedUntil.Equal(until) {
+			t.Errorf("after update: got %+v", got)
+		}
+
+		if err := store.Delete(ctx, "bob"); err != nil {
+			t.Fatalf("deleting: %v", err)
+		}
+		if _, err := store.Get(ctx, "bob"); !errors.Is(err, errUserNotFound) {
+			t.Errorf("Get after delete: got %v, want errUserNotFound", err)
+		}
+		if err := store.Update(ctx, bob); !errors.Is(err, errUserNotFound) {
+			t.Errorf("Update after delete: got %v, want errUserNotFound", err)
+		}
+		if err := store.Delete(ctx, "bob"); !errors.Is(err, errUserNotFound) {
+			t.Errorf("Delete after delete: got %v, want errUserNotFound", err)
+		}
+		if _, err := store.RecordFailedLogin(ctx, "bob", time.Now()); !errors.Is(err, errUserNotFound) {
+			t.Errorf("RecordFailedLogin after delete: got %v, want errUserNotFound", err)
+		}
+		if err := store.ResetFailedLogins(ctx, "bob", time.Now()); !errors.Is(err, errUserNotFound) {
+			t.Errorf("ResetFailedLogins after delete: got %v, want errUserNotFound", err)
+		}
+	})
+}
+
+// TestFileUserStoreSharesFile checks that a store picks up changes written by another store on
+// the same file, as the server does for changes made by the user commands.
+func TestFileUserStoreSharesFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.json")
+	server, cli := NewFileUserStore(path), NewFileUserStore(path)
+
+	if _, err := server.Get(ctx, "bob"); !errors.Is(err, errUserNotFound) {
+		t.Fatalf("empty store: got %v, want errUserNotFound", err)
+	}
+	newTestUser(t, cli, "bob")
+	if _, err := server.Get(ctx, "bob"); err != nil {
+		t.Errorf("user added by another store: %v", err)
+	}
+}
+
+// TestFileUserStoreWaitsForLock checks that a change waits while another process, such as a user
+// command, holds the store's lock file.
+func TestFileUserStoreWaitsForLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	store := NewFileUserStore(path)
+	newTestUser(t, store, "bob")
+
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := store.RecordFailedLogin(context.Background(), "bob", time.Now())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		unlock()
+		t.Fatalf("the change did not wait for the lock: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestBootstrapUser checks that the bootstrap user is only created in an empty store.
+func TestBootstrapUser(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileUserStore(filepath.Join(t.TempDir(), "users.json"))
+
+	if _, err := bootstrapUser(ctx, store, "bad name", testPassword); err == nil {
+		t.Error("an invalid username was accepted")
+	}
+	if created, err := bootstrapUser(ctx, store, "admin", testPassword); !created || err != nil {
+		t.Fatalf("empty store: got %v, %v", created, err)
+	}
+	if created, err := bootstrapUser(ctx, store, "other", testPassword); created || err != nil {
+		t.Errorf("store with users: got %v, %v", created, err)
+	}
+	if _, err := authenticate(ctx, store, "admin", testPassword); err != nil {
+		t.Errorf("logging in as the bootstrap user: %v", err)
+	}
+}
+
+// TestAuthenticate checks logins of unknown, disabled, locked and regular users.
+func TestAuthenticate(t *testing.T) {
+	forEachUserStore(t, func(t *testing.T, store UserStore) {
+		ctx := context.Background()
+		newTestUser(t, store, "bob")
+		carol := newTestUser(t, store, "carol")
+		carol.Disabled = true
+		if err := store.Update(ctx, carol); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			username, password string
+			want               error
+		}{
+			{"bob", testPassword, nil},
+			{"bob", "wrong", errInvalidCredentials},
+			{"nobody", testPassword, errInvalidCredentials},
+			{"carol", testPassword, errUserDisabled},
+			{"carol", "wrong", errInvalidCredentials},
+		}
+		for _, tt := range tests {
+			if _, err := authenticate(ctx, store, tt.username, tt.password); !errors.Is(err, tt.want) {
+				t.Errorf("%s with %q: got %v, want %v", tt.username, tt.password, err, tt.want)
+			}
+		}
+
+		bob, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bob.FailedLogins != 1 {
+			t.Errorf("bob has %d failed logins, want 1", bob.FailedLogins)
+		}
+		if _, err := authenticate(ctx, store, "bob", testPassword); err != nil {
+			t.Fatal(err)
+		}
+		if bob, _ = store.Get(ctx, "bob"); bob.FailedLogins != 0 {
+			t.Errorf("a successful login left %d failed logins", bob.FailedLogins)
+		}
+	})
+}
+
+// TestAuthenticateLockout checks that repeated failures lock an account for a while, that a
+// locked account refuses even the right password, and that the lockout ends by itself.
+func TestAuthenticateLockout(t *testing.T) {
+	forEachUserStore(t, func(t *testing.T, store UserStore) {
+		ctx := context.Background()
+		newTestUser(t, store, "bob")
+
+		for i := 0; i < maxFailedLogins; i++ {
+			if _, err := authenticate(ctx, store, "bob", "wrong"); !errors.Is(err, errInvalidCredentials) {
+				t.Fatalf("failure %d: got %v, want errInvalidCredentials", i+1, err)
+			}
+		}
+		if _, err := authenticate(ctx, store, "bob", testPassword); !errors.Is(err, errUserLocked) {
+			t.Fatalf("right password while locked: got %v, want errUserLocked", err)
+		}
+
+		bob, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bob.Locked || bob.LockedUntil == nil {
+			t.Fatalf("after %d failures: got %+v, want a temporary lock", maxFailedLogins, bob)
+		}
+		if left := time.Until(*bob.LockedUntil); left <= 0 || left > lockoutBase {
+			t.Errorf("locked for %s, want at most %s", left, lockoutBase)
+		}
+
+		// Let the lockout end
+		expired := time.Now().Add(-time.Second)
+		bob.LockedUntil = &expired
+		if err := store.Update(ctx, bob); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := authenticate(ctx, store, "bob", testPassword); err != nil {
+			t.Fatalf("login after the lockout ended: %v", err)
+		}
+		if bob, _ = store.Get(ctx, "bob"); bob.FailedLogins != 0 || bob.LockedUntil != nil {
+			t.Errorf("a successful login left %+v", bob)
+		}
+
+		// A lock set by an admin does not end by itself
+		bob.Locked = true
+		if err := store.Update(ctx, bob); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := authenticate(ctx, store, "bob", testPassword); !errors.Is(err, errUserLocked) {
+			t.Errorf("login while locked by an admin: got %v, want errUserLocked", err)
+		}
+	})
+}
+
+// TestRecordFailedLoginConcurrent checks that concurrent failed logins are all counted.
+func TestRecordFailedLoginConcurrent(t *testing.T) {
+	forEachUserStore(t, func(t *testing.T, store UserStore) {
+		ctx := context.Background()
+		newTestUser(t, store, "bob")
+
+		const attempts = 20
+		var wg sync.WaitGroup
+		for i := 0; i < attempts; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := store.RecordFailedLogin(ctx, "bob", time.Now()); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		bob, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bob.FailedLogins != attempts {
+			t.Errorf("counted %d failed logins, want %d", bob.FailedLogins, attempts)
+		}
+		if bob.LockedUntil == nil || time.Until(*bob.LockedUntil) <= lockoutMax-time.Minute {
+			t.Errorf("after %d failures: locked until %v, want about %s from now", attempts, bob.LockedUntil, lockoutMax)
+		}
+	})
+}
+
+// TestResetFailedLoginsKeepsOtherChanges checks that clearing failed logins after a successful
+// login leaves changes made meanwhile, such as an admin disabling or locking the account, alone.
+func TestResetFailedLoginsKeepsOtherChanges(t *testing.T) {
+	forEachUserStore(t, func(t *testing.T, store UserStore) {
+		ctx := context.Background()
+		newTestUser(t, store, "bob")
+		for i := 0; i < maxFailedLogins; i++ {
+			if _, err := store.RecordFailedLogin(ctx, "bob", time.Now()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		bob, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		bob.Disabled, bob.Locked = true, true
+		if err := store.Update(ctx, bob); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.ResetFailedLogins(ctx, "bob", time.Now()); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := store.Get(ctx, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Disabled || !got.Locked || got.FailedLogins != 0 || got.LockedUntil != nil {
+			t.Errorf("after the reset: got %+v", got)
+		}
+	})
+}
+
+// TestLockoutDuration checks the lockout backoff.
+func TestLockoutDuration(t *testing.T) {
+	for failures, want := range map[int]time.Duration{
+		0:                   0,
+		maxFailedLogins - 1: 0,
+		maxFailedLogins:     lockoutBase,
+		maxFailedLogins + 1: 2 * lockoutBase,
+		maxFailedLogins + 3: 8 * lockoutBase,
+		maxFailedLogins + 6: lockoutMax,
+		1000:                lockoutMax,
+	} {
+		if got := lockoutDuration(failures); got != want {
+			t.Errorf("lockoutDuration(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+// TestAuthenticateUnknownUserTakesAsLong checks that logins for unknown users still pay for a
+// password check, so response times do not reveal which usernames exist.
+func TestAuthenticateUnknownUserTakesAsLong(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileUserStore(filepath.Join(t.TempDir(), "users.json"))
+	newTestUser(t, store, "bob")
+	burnPasswordCheck("warm up the dummy hash")
+
+	fastest := func(username string) time.Duration {
+		best := time.Duration(1<<63 - 1)
+		for i := 0; i < 3; i++ {
+			start := time.Now()
+			authenticate(ctx, store, username, "wrong")
+			if elapsed := time.Since(start); elapsed < best {
+				best = elapsed
+			}
+		}
+		return best
+	}
+
+	known, unknown := fastest("bob"), fastest("nobody")
+	if unknown < known/4 {
+		t.Errorf("unknown user took %s, known user %s: the password check is skipped", unknown, known)
+	}
+}